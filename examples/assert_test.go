@@ -0,0 +1,81 @@
+package examples
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/mstrYoda/go-arctest/pkg/arctest"
+)
+
+// recordingT records the failures reported by assertions instead of failing the test
+type recordingT struct {
+	errors []string
+	logs   []string
+}
+
+func (r *recordingT) Helper() {}
+
+func (r *recordingT) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func (r *recordingT) Logf(format string, args ...interface{}) {
+	r.logs = append(r.logs, fmt.Sprintf(format, args...))
+}
+
+// TestFluentAssertions demonstrates the assertion API, which reports violations through the test
+func TestFluentAssertions(t *testing.T) {
+	arch, err := arctest.New("./example_project")
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	if err := arch.ParsePackages("domain", "application", "infrastructure", "presentation", "utils"); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	domainLayer, err := arctest.NewLayer("Domain", "^domain$")
+	if err != nil {
+		t.Fatalf("Failed to create domain layer: %v", err)
+	}
+
+	applicationLayer, err := arctest.NewLayer("Application", "^application$")
+	if err != nil {
+		t.Fatalf("Failed to create application layer: %v", err)
+	}
+
+	presentationLayer, err := arctest.NewLayer("Presentation", "^presentation$")
+	if err != nil {
+		t.Fatalf("Failed to create presentation layer: %v", err)
+	}
+
+	utilsLayer, err := arctest.NewLayer("Utils", "^utils$")
+	if err != nil {
+		t.Fatalf("Failed to create utils layer: %v", err)
+	}
+
+	layeredArch := arch.NewLayeredArchitecture(domainLayer, applicationLayer, presentationLayer, utilsLayer)
+
+	// Passing assertions can be chained directly on the test
+	arctest.Assert(t, layeredArch).
+		HasNoCycles().
+		Layer("Domain").DoesNotDependOn("Application").
+		Layer("Presentation").StructNamesMatch("Handler$")
+
+	// The domain -> utils dependency is reported as a test failure
+	recorder := &recordingT{}
+	arctest.Assert(recorder, layeredArch).
+		Layer("Domain").DoesNotDependOn("Utils").
+		Layer("Missing")
+
+	if len(recorder.errors) != 2 {
+		t.Fatalf("Expected two failures, got %v", recorder.errors)
+	}
+	if !strings.Contains(recorder.errors[0], `imports "github.com/mstrYoda/go-arctest/examples/example_project/utils"`) {
+		t.Errorf("Expected the domain -> utils dependency to be reported, got %s", recorder.errors[0])
+	}
+	if recorder.errors[1] != `Layer "Missing" not found` {
+		t.Errorf("Expected the unknown layer to be reported, got %s", recorder.errors[1])
+	}
+}
@@ -0,0 +1,93 @@
+package examples
+
+import (
+	"go/build"
+	"testing"
+	"testing/fstest"
+
+	"github.com/mstrYoda/go-arctest/pkg/arctest"
+)
+
+// platformFS contains platform-specific files with different imports
+var platformFS = fstest.MapFS{
+	"go.mod": {Data: []byte("module example.com/platform\n\ngo 1.20\n")},
+	"storage/storage.go": {Data: []byte(`package storage
+
+// Store persists data
+type Store struct{}
+`)},
+	"storage/storage_linux.go": {Data: []byte(`package storage
+
+import "golang.org/x/sys/unix"
+
+// LinuxStore persists data using Linux system calls
+type LinuxStore struct{}
+
+var _ = unix.Getpid
+`)},
+	"storage/storage_windows.go": {Data: []byte(`package storage
+
+import "golang.org/x/sys/windows"
+
+// WindowsStore persists data using Windows system calls
+type WindowsStore struct{}
+
+var _ = windows.GetCurrentProcessId
+`)},
+	"storage/cloud.go": {Data: []byte(`//go:build cloud
+
+package storage
+
+import "cloud.google.com/go/storage"
+
+// CloudStore persists data in a bucket
+type CloudStore struct {
+	client *storage.Client
+}
+`)},
+}
+
+// TestBuildConstraints demonstrates how to analyze the code built for a specific platform
+func TestBuildConstraints(t *testing.T) {
+	tests := []struct {
+		name    string
+		goos    string
+		tags    []string
+		structs []string
+	}{
+		{name: "linux", goos: "linux", structs: []string{"Store", "LinuxStore"}},
+		{name: "windows", goos: "windows", structs: []string{"Store", "WindowsStore"}},
+		{name: "linux with cloud tag", goos: "linux", tags: []string{"cloud"}, structs: []string{"Store", "LinuxStore", "CloudStore"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := build.Default
+			ctx.GOOS = tt.goos
+			ctx.GOARCH = "amd64"
+			ctx.BuildTags = tt.tags
+
+			arch, err := arctest.NewFromFS(platformFS, ".", arctest.WithBuildContext(ctx))
+			if err != nil {
+				t.Fatalf("Failed to create architecture: %v", err)
+			}
+
+			if err := arch.ParsePackages(); err != nil {
+				t.Fatalf("Failed to parse packages: %v", err)
+			}
+
+			pkg := arch.GetPackage("storage")
+			if pkg == nil {
+				t.Fatal("Expected the storage package to be parsed")
+			}
+			if len(pkg.Structs) != len(tt.structs) || len(pkg.Imports) != len(tt.structs)-1 {
+				t.Fatalf("Expected structs %v, got %d structs and imports %v", tt.structs, len(pkg.Structs), pkg.Imports)
+			}
+			for _, name := range tt.structs {
+				if pkg.Structs[name] == nil {
+					t.Errorf("Expected struct %s to be parsed", name)
+				}
+			}
+		})
+	}
+}
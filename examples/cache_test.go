@@ -0,0 +1,82 @@
+package examples
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mstrYoda/go-arctest/pkg/arctest"
+)
+
+// TestParseCache demonstrates how to reuse parse results across runs
+func TestParseCache(t *testing.T) {
+	projectDir := t.TempDir()
+	cacheDir := filepath.Join(t.TempDir(), "cache")
+
+	src, err := os.ReadFile("./example_project/domain/user.go")
+	if err != nil {
+		t.Fatalf("Failed to read fixture: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Join(projectDir, "domain"), 0o755); err != nil {
+		t.Fatalf("Failed to create package directory: %v", err)
+	}
+	userFile := filepath.Join(projectDir, "domain", "user.go")
+	if err := os.WriteFile(userFile, src, 0o644); err != nil {
+		t.Fatalf("Failed to write fixture: %v", err)
+	}
+
+	parse := func() (*arctest.Package, string) {
+		logger := &recordingLogger{}
+		arch, err := arctest.New(projectDir, arctest.WithCache(cacheDir), arctest.WithLogger(logger))
+		if err != nil {
+			t.Fatalf("Failed to create architecture: %v", err)
+		}
+		if err := arch.ParsePackages(); err != nil {
+			t.Fatalf("Failed to parse packages: %v", err)
+		}
+		return arch.GetPackage("domain"), strings.Join(logger.messages, "\n")
+	}
+
+	parsed, traced := parse()
+	if strings.Contains(traced, "from cache") {
+		t.Fatalf("Expected the first run to parse the sources, got:\n%s", traced)
+	}
+
+	cached, traced := parse()
+	if !strings.Contains(traced, "Loaded package domain from cache") {
+		t.Fatalf("Expected the second run to load the package from the cache, got:\n%s", traced)
+	}
+	if !reflect.DeepEqual(structNames(parsed), structNames(cached)) || cached.Structs["User"].Pkg != cached {
+		t.Errorf("Expected the cached package to match the parsed one")
+	}
+
+	// Changing a source file invalidates the cached package
+	src = append(src, []byte("\n// Admin is a privileged user\ntype Admin struct{}\n")...)
+	if err := os.WriteFile(userFile, src, 0o644); err != nil {
+		t.Fatalf("Failed to update fixture: %v", err)
+	}
+	later := time.Now().Add(time.Minute)
+	if err := os.Chtimes(userFile, later, later); err != nil {
+		t.Fatalf("Failed to update modification time: %v", err)
+	}
+
+	updated, traced := parse()
+	if strings.Contains(traced, "from cache") {
+		t.Errorf("Expected the changed package to be parsed again, got:\n%s", traced)
+	}
+	if _, ok := updated.Structs["Admin"]; !ok {
+		t.Errorf("Expected the new struct to be found after the change")
+	}
+}
+
+// structNames returns the names of the structs declared in a package
+func structNames(pkg *arctest.Package) map[string]bool {
+	names := map[string]bool{}
+	for name := range pkg.Structs {
+		names[name] = true
+	}
+	return names
+}
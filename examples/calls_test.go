@@ -0,0 +1,106 @@
+package examples
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/mstrYoda/go-arctest/pkg/arctest"
+)
+
+// TestMustNotCall demonstrates how to forbid calls such as panic or os.Exit in a layer
+func TestMustNotCall(t *testing.T) {
+	fsys := fstest.MapFS{
+		"go.mod": {Data: []byte("module example.com/calls\n\ngo 1.20\n")},
+		"domain/order.go": {Data: []byte(`package domain
+
+import (
+	"log"
+	osx "os"
+)
+
+// Logger logs messages
+type Logger interface {
+	Fatal(v ...interface{})
+}
+
+// Order is a customer order
+type Order struct {
+	Total int
+	log   Logger
+}
+
+var defaultOrder = mustOrder(1)
+
+// Validate checks the order and terminates the process if it's invalid
+func (o *Order) Validate() {
+	if o.Total < 0 {
+		panic("negative total")
+	}
+	if o.Total == 0 {
+		osx.Exit(1)
+	}
+
+	// Calls through fields are not calls of the log package
+	o.log.Fatal("unreachable")
+
+	defer func() {
+		log.Fatalf("order %d", o.Total)
+	}()
+}
+
+func mustOrder(total int) *Order {
+	return &Order{Total: total}
+}
+`)},
+		"cmd/main.go": {Data: []byte(`package main
+
+import "os"
+
+func main() {
+	os.Exit(0)
+}
+`)},
+	}
+
+	arch, err := arctest.NewFromFS(fsys, ".")
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	if err := arch.ParsePackages(); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	domainLayer, err := arctest.NewLayer("Domain", "^domain$")
+	if err != nil {
+		t.Fatalf("Failed to create domain layer: %v", err)
+	}
+	arch.NewLayeredArchitecture(domainLayer)
+
+	violations, err := domainLayer.MustNotCallDetailed(`^(panic|os\.Exit|log\.Fatal.*)$`)
+	if err != nil {
+		t.Fatalf("Failed to check calls: %v", err)
+	}
+
+	// The aliased os.Exit and the log.Fatalf inside the closure are found, o.log.Fatal
+	// and the os.Exit outside the layer are not
+	expected := []int{24, 27, 34}
+	if len(violations) != len(expected) {
+		t.Fatalf("Expected %d forbidden calls, got %v", len(expected), violations)
+	}
+	for i, v := range violations {
+		if v.Line != expected[i] {
+			t.Errorf("Expected a forbidden call on line %d, got %s", expected[i], v)
+		}
+		t.Logf("  ✓ %s", v)
+	}
+
+	// Package-level variable initializers are covered as well
+	violations, err = domainLayer.MustNotCallDetailed(`^mustOrder$`)
+	if err != nil {
+		t.Fatalf("Failed to check calls: %v", err)
+	}
+	if len(violations) != 1 || violations[0].Line != 19 {
+		t.Errorf("Expected the call in the variable initializer to be reported, got %v", violations)
+	}
+}
@@ -0,0 +1,55 @@
+package examples
+
+import (
+	"testing"
+
+	"github.com/mstrYoda/go-arctest/pkg/arctest"
+)
+
+// TestCompositeTypes verifies that map, slice, channel, func and variadic types are rendered
+func TestCompositeTypes(t *testing.T) {
+	arch, err := arctest.New("./example_project")
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	if err := arch.ParsePackages("infrastructure"); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	bus := arch.GetPackage("infrastructure").Structs["UserEventBus"]
+
+	fields := map[string]string{}
+	for _, f := range bus.Fields {
+		fields[f.Name] = f.Type
+	}
+	if got := fields["subscribers"]; got != "map[string][]func(*domain.User) error" {
+		t.Errorf("Unexpected type for subscribers field: %q", got)
+	}
+	if got := fields["events"]; got != "chan *domain.User" {
+		t.Errorf("Unexpected type for events field: %q", got)
+	}
+
+	methods := map[string]*arctest.Method{}
+	for _, m := range bus.Methods {
+		methods[m.Name] = m
+	}
+	if got := methods["Publish"].Params[0].Type; got != "...*domain.User" {
+		t.Errorf("Unexpected type for Publish parameter: %q", got)
+	}
+	if got := methods["Events"].ReturnType; got != "<-chan *domain.User" {
+		t.Errorf("Unexpected return type for Events: %q", got)
+	}
+
+	// Parameter rules look through containers to the named type behind them
+	rule, err := arch.MethodsShouldUseInterfaceParameters(".*EventBus$", "Publish", "User$")
+	if err != nil {
+		t.Fatalf("Failed to create parameter rule: %v", err)
+	}
+	if err := arch.ParsePackages("domain"); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+	if valid, _ := arch.ValidateMethodParameters([]*arctest.ParameterRule{rule}); valid {
+		t.Error("Expected Publish to be reported for using the concrete domain.User struct")
+	}
+}
@@ -0,0 +1,118 @@
+package examples
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/mstrYoda/go-arctest/pkg/arctest"
+)
+
+// TestConstructorsReturnStructs demonstrates how to enforce "accept interfaces, return structs"
+func TestConstructorsReturnStructs(t *testing.T) {
+	arch, err := arctest.New("./example_project")
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	if err := arch.ParsePackages("domain", "application", "infrastructure"); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	// Package-level functions are recorded alongside methods
+	pkg := arch.GetPackage("application")
+	functions := map[string]*arctest.Function{}
+	for _, f := range pkg.Functions {
+		functions[f.Name] = f
+	}
+	if f, ok := functions["NewUserService"]; !ok || f.ReturnType != "*UserService" || len(f.Params) != 1 {
+		t.Errorf("Expected NewUserService to be recorded with its signature, got %+v", f)
+	}
+	if _, ok := functions["generateID"]; !ok {
+		t.Errorf("Expected unexported functions to be recorded as well")
+	}
+
+	violations, err := arch.ConstructorsReturnStructs(".*", "^New")
+	if err != nil {
+		t.Fatalf("Failed to check constructors: %v", err)
+	}
+
+	if len(violations) != 1 || !strings.Contains(violations[0], `"NewAuditedUserRepository"`) {
+		t.Fatalf("Expected only NewAuditedUserRepository to be reported, got %v", violations)
+	}
+	t.Logf("  ✓ %s", violations[0])
+	// Constructor rules with a warning severity don't fail validation
+	rule, err := arctest.NewConstructorRule(".*", "^New")
+	if err != nil {
+		t.Fatalf("Failed to create constructor rule: %v", err)
+	}
+	rule.Severity = arctest.SeverityWarning
+
+	valid, detailed := arch.ValidateConstructorsDetailed([]*arctest.ConstructorRule{rule})
+	if !valid || len(detailed) != 1 || !detailed[0].IsWarning() {
+		t.Errorf("Expected a single warning, got %v", detailed)
+	}
+}
+
+// TestConstructorReturnTypes verifies how the return types of constructors are classified
+func TestConstructorReturnTypes(t *testing.T) {
+	arch, err := arctest.NewFromFS(fstest.MapFS{
+		"go.mod": {Data: []byte("module example.com/shop\n\ngo 1.20\n")},
+		"domain/repository.go": {Data: []byte(`package domain
+
+type Repository interface{ Save() }
+
+type Store = Repository
+`)},
+		"postgres/repository.go": {Data: []byte(`package postgres
+
+import d "example.com/shop/domain"
+
+type Repository struct{}
+
+func (r *Repository) Save() {}
+
+func NewRepository() (*Repository, error) { return &Repository{}, nil }
+
+func NewAliased() d.Repository { return &Repository{} }
+
+func NewStore() d.Store { return &Repository{} }
+
+func NewAny() any { return &Repository{} }
+
+func NewEmpty() interface{} { return &Repository{} }
+
+func NewErr() error { return nil }
+`)},
+	}, ".")
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	if err := arch.ParsePackages(); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	violations, err := arch.ConstructorsReturnStructs("^postgres$", "^New")
+	if err != nil {
+		t.Fatalf("Failed to check constructors: %v", err)
+	}
+
+	for _, v := range violations {
+		t.Logf("  ✓ %s", v)
+	}
+	cases := map[string]bool{
+		"NewRepository": false, // the local struct, despite the domain interface of the same name
+		"NewAliased":    true,  // an interface imported under an alias
+		"NewStore":      true,  // an alias of an interface
+		"NewAny":        true,
+		"NewEmpty":      true,
+		"NewErr":        false, // errors are allowed
+	}
+	for name, expected := range cases {
+		reported := strings.Contains(strings.Join(violations, "\n"), `Function "`+name+`"`)
+		if reported != expected {
+			t.Errorf("Expected %s to be reported: %v, got %v", name, expected, violations)
+		}
+	}
+}
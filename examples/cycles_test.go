@@ -0,0 +1,47 @@
+package examples
+
+import (
+	"testing"
+
+	"github.com/mstrYoda/go-arctest/pkg/arctest"
+)
+
+// TestImportCycles demonstrates how to detect packages that import each other
+func TestImportCycles(t *testing.T) {
+	arch, err := arctest.New("./testdata/cycles")
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	if err := arch.ParsePackages(); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	cycles := arch.FindCycles()
+	if len(cycles) != 1 {
+		t.Fatalf("Expected exactly one cycle, got %v", cycles)
+	}
+	if len(cycles[0]) != 2 || cycles[0][0] != "billing" || cycles[0][1] != "orders" {
+		t.Errorf("Expected cycle billing -> orders, got %v", cycles[0])
+	}
+
+	valid, violations := arch.HasNoCycles()
+	if valid {
+		t.Error("Expected import cycle violations, but none were found!")
+	}
+	for _, violation := range violations {
+		t.Logf("  ✓ %s", violation)
+	}
+
+	// The example project itself is free of cycles
+	exampleArch, err := arctest.New("./example_project")
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+	if err := exampleArch.ParsePackages(); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+	if valid, violations := exampleArch.HasNoCycles(); !valid {
+		t.Errorf("Unexpected import cycles: %v", violations)
+	}
+}
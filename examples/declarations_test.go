@@ -0,0 +1,109 @@
+package examples
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/mstrYoda/go-arctest/pkg/arctest"
+)
+
+// TestGroupedDeclarations verifies that every spec in grouped var/const blocks is recorded
+func TestGroupedDeclarations(t *testing.T) {
+	arch, err := arctest.New("./example_project")
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	if err := arch.ParsePackages("domain"); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	pkg := arch.GetPackage("domain")
+	if pkg == nil {
+		t.Fatal("Expected domain package to be parsed")
+	}
+
+	if len(pkg.Constants) != 4 {
+		t.Errorf("Expected 4 constants, got %d", len(pkg.Constants))
+	}
+	for _, c := range pkg.Constants {
+		if c.Type != "UserStatus" {
+			t.Errorf("Expected constant %s to have type UserStatus, got %q", c.Name, c.Type)
+		}
+	}
+
+	if len(pkg.Variables) != 5 {
+		t.Errorf("Expected 5 variables, got %d", len(pkg.Variables))
+	}
+	types := map[string]string{}
+	for _, v := range pkg.Variables {
+		types[v.Name] = v.Type
+	}
+	if types["ErrInvalidEmail"] != "error" {
+		t.Errorf("Expected ErrInvalidEmail to have type error, got %q", types["ErrInvalidEmail"])
+	}
+	if types["defaultStatus"] != "UserStatus" {
+		t.Errorf("Expected defaultStatus to have type UserStatus, got %q", types["defaultStatus"])
+	}
+	if types["ErrUserNotFound"] != "" {
+		t.Errorf("Expected ErrUserNotFound to have an inferred type, got %q", types["ErrUserNotFound"])
+	}
+}
+
+// TestMustNotHaveMutableGlobals demonstrates how to only allow constants at package level
+func TestMustNotHaveMutableGlobals(t *testing.T) {
+	arch, err := arctest.NewFromFS(fstest.MapFS{
+		"go.mod": {Data: []byte("module example.com/shop\n\ngo 1.20\n")},
+		"domain/order.go": {Data: []byte(`package domain
+
+// Status is the status of an order
+type Status int
+
+const (
+	Pending Status = iota
+	Shipped
+)
+
+// Repository stores orders
+type Repository interface{}
+
+type repository struct{}
+
+var _ Repository = (*repository)(nil)
+
+var (
+	defaultStatus = Pending
+	retries, timeout int
+)
+`)},
+		"application/service.go": {Data: []byte("package application\n\nvar cache = map[string]string{}\n")},
+	}, ".")
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	if err := arch.ParsePackages(); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	domainLayer, err := arctest.NewLayer("Domain", "^domain$")
+	if err != nil {
+		t.Fatalf("Failed to create domain layer: %v", err)
+	}
+	arch.NewLayeredArchitecture(domainLayer)
+
+	violations, err := domainLayer.MustNotHaveMutableGlobals()
+	if err != nil {
+		t.Fatalf("Failed to check globals: %v", err)
+	}
+	if len(violations) != 3 {
+		t.Fatalf("Expected every name of the var block to be reported, got %v", violations)
+	}
+	for idx, name := range []string{"defaultStatus", "retries", "timeout"} {
+		if !strings.Contains(violations[idx], `variable "`+name+`"`) {
+			t.Errorf("Expected %q to be reported, got %s", name, violations[idx])
+		}
+		t.Logf("  ✓ %s", violations[idx])
+	}
+}
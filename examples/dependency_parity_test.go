@@ -0,0 +1,148 @@
+package examples
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+	"testing/fstest"
+
+	"github.com/mstrYoda/go-arctest/pkg/arctest"
+)
+
+// dependencyPairs returns the sorted source and target packages of the violations
+func dependencyPairs(violations []arctest.Violation) []string {
+	pairs := make([]string, 0, len(violations))
+	for _, v := range violations {
+		pairs = append(pairs, v.SourcePackage+" -> "+v.TargetPackage)
+	}
+	sort.Strings(pairs)
+	return pairs
+}
+
+// TestDependencyCheckParity verifies that a layered architecture and the equivalent list of
+// dependency rules report the same imports
+func TestDependencyCheckParity(t *testing.T) {
+	arch, err := arctest.New("./example_project")
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	if err := arch.ParsePackages(); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	layers := map[string]*arctest.Layer{}
+	names := []string{"domain", "application", "infrastructure", "presentation", "utils"}
+	for _, name := range names {
+		layer, err := arctest.NewLayer(name, "^"+name+"$")
+		if err != nil {
+			t.Fatalf("Failed to create %s layer: %v", name, err)
+		}
+		layers[name] = layer
+	}
+	layeredArch := arch.NewLayeredArchitecture(layers["domain"], layers["application"], layers["infrastructure"], layers["presentation"], layers["utils"])
+
+	// Forbidden dependencies, checked by the layered architecture and as a list of rules
+	layeredArch.SetPolicyMode(arctest.AllowByDefault)
+	rules := []*arctest.DependencyRule{}
+	for _, dependency := range [][2]string{{"domain", "utils"}, {"domain", "application"}, {"application", "utils"}, {"presentation", "domain"}} {
+		if err := layers[dependency[0]].MustNotDependOnLayer(layers[dependency[1]]); err != nil {
+			t.Fatalf("Failed to forbid layer dependency: %v", err)
+		}
+		rule, err := layers[dependency[0]].DoesNotDependOnLayer(layers[dependency[1]])
+		if err != nil {
+			t.Fatalf("Failed to create dependency rule: %v", err)
+		}
+		rules = append(rules, rule)
+	}
+
+	layered, err := layeredArch.CheckDetailed()
+	if err != nil {
+		t.Fatalf("Failed to check layered architecture: %v", err)
+	}
+	_, ruleList := arch.ValidateDependenciesWithRulesDetailed(rules)
+
+	if len(layered) == 0 || !reflect.DeepEqual(dependencyPairs(layered), dependencyPairs(ruleList)) {
+		t.Fatalf("Expected both checks to report the same imports, got %v and %v", dependencyPairs(layered), dependencyPairs(ruleList))
+	}
+	for _, pair := range dependencyPairs(layered) {
+		t.Logf("  ✓ %s", pair)
+	}
+
+	// Path-style target patterns match the import path and the package path alike
+	for _, target := range []string{"^utils$", ".*/utils$"} {
+		rule, err := arch.DoesNotDependOn("^domain$", target)
+		if err != nil {
+			t.Fatalf("Failed to create dependency rule: %v", err)
+		}
+		if valid, violations := arch.ValidateDependenciesWithRules([]*arctest.DependencyRule{rule}); valid {
+			t.Errorf("Expected the domain -> utils import to be reported for target %q", target)
+		} else if len(violations) != 1 {
+			t.Errorf("Expected a single violation for target %q, got %v", target, violations)
+		}
+	}
+}
+
+// TestStandardLibraryParity verifies that a standard library import gets the same verdict
+// from a list of dependency rules and from a layered architecture
+func TestStandardLibraryParity(t *testing.T) {
+	arch, err := arctest.NewFromFS(fstest.MapFS{
+		"go.mod":         {Data: []byte("module example.com/shop\n\ngo 1.20\n")},
+		"httpapi/api.go": {Data: []byte("package httpapi\n")},
+		"domain/user.go": {Data: []byte(`package domain
+
+import (
+	_ "net/http"
+
+	_ "example.com/shop/httpapi"
+)
+`)},
+	}, ".")
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	if err := arch.ParsePackages(); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	domain, err := arctest.NewLayer("domain", "^domain$")
+	if err != nil {
+		t.Fatalf("Failed to create domain layer: %v", err)
+	}
+	web, err := arctest.NewLayer("web", ".*http.*")
+	if err != nil {
+		t.Fatalf("Failed to create web layer: %v", err)
+	}
+	layeredArch := arch.NewLayeredArchitecture(domain, web)
+	layeredArch.SetPolicyMode(arctest.AllowByDefault)
+	if err := domain.MustNotDependOnLayer(web); err != nil {
+		t.Fatalf("Failed to forbid layer dependency: %v", err)
+	}
+
+	layered, err := layeredArch.CheckDetailed()
+	if err != nil {
+		t.Fatalf("Failed to check layered architecture: %v", err)
+	}
+
+	rule, err := arch.DoesNotDependOn("^domain$", ".*http.*")
+	if err != nil {
+		t.Fatalf("Failed to create dependency rule: %v", err)
+	}
+	_, ruleList := arch.ValidateDependenciesWithRulesDetailed([]*arctest.DependencyRule{rule})
+
+	expected := []string{"domain -> example.com/shop/httpapi"}
+	if !reflect.DeepEqual(dependencyPairs(layered), expected) || !reflect.DeepEqual(dependencyPairs(ruleList), expected) {
+		t.Fatalf("Expected both checks to report only %v, got %v and %v", expected, dependencyPairs(layered), dependencyPairs(ruleList))
+	}
+	t.Logf("  ✓ net/http is skipped by both checks")
+
+	// Rules naming a standard library package explicitly still apply to it
+	moduleRule, err := domain.MustNotImportModule("net/http")
+	if err != nil {
+		t.Fatalf("Failed to create module rule: %v", err)
+	}
+	if _, violations := arch.ValidateDependenciesWithRulesDetailed([]*arctest.DependencyRule{moduleRule}); !reflect.DeepEqual(dependencyPairs(violations), []string{"domain -> net/http"}) {
+		t.Errorf("Expected the net/http import to be reported, got %v", dependencyPairs(violations))
+	}
+}
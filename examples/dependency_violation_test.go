@@ -1,7 +1,9 @@
 package examples
 
 import (
+	"strings"
 	"testing"
+	"testing/fstest"
 
 	"github.com/mstrYoda/go-arctest/pkg/arctest"
 )
@@ -141,3 +143,126 @@ func TestDependencyViolationWithLayers(t *testing.T) {
 		}
 	}
 }
+
+// TestMayOnlyDependOn demonstrates how to allow-list the dependencies of packages
+func TestMayOnlyDependOn(t *testing.T) {
+	arch, err := arctest.New("./testdata/external")
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	if err := arch.ParsePackages(); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	rule, err := arch.MayOnlyDependOn("^domain$", ".*/shared$", `^github\.com/google/uuid(/.*)?$`)
+	if err != nil {
+		t.Fatalf("Failed to create dependency rule: %v", err)
+	}
+
+	// Standard library imports are always allowed, everything else must match a target
+	valid, violations := arch.ValidateDependenciesWithRulesDetailed([]*arctest.DependencyRule{rule})
+	if valid || len(violations) != 1 || violations[0].TargetPackage != "golang.org/x/text/language" {
+		t.Fatalf("Expected only golang.org/x/text/language to be reported, got %v", violations)
+	}
+	t.Logf("  ✓ %s", violations[0])
+
+	if _, err := arch.MayOnlyDependOn("^domain$"); err == nil {
+		t.Error("Expected an error for a rule without allowed targets")
+	}
+}
+
+// TestDependencyRuleAcrossSubdomains demonstrates how capture groups keep subdomains apart
+func TestDependencyRuleAcrossSubdomains(t *testing.T) {
+	arch, err := arctest.NewFromFS(fstest.MapFS{
+		"go.mod":                        {Data: []byte("module example.com/shop\n\ngo 1.20\n")},
+		"billing/infrastructure/db.go":  {Data: []byte("package infrastructure\n")},
+		"shipping/infrastructure/db.go": {Data: []byte("package infrastructure\n")},
+		"shipping/domain/shipment.go":   {Data: []byte("package domain\n")},
+		"billing/domain/invoice.go": {Data: []byte(`package domain
+
+import (
+	_ "example.com/shop/billing/infrastructure"
+	_ "example.com/shop/shipping/infrastructure"
+)
+`)},
+	}, ".")
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	if err := arch.ParsePackages(); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	across, err := arch.DoesNotDependOnAcross(`^(?P<sd>[^/]+)/domain$`, `^(?P<sd>[^/]+)/infrastructure$`, "sd")
+	if err != nil {
+		t.Fatalf("Failed to create capture rule: %v", err)
+	}
+
+	valid, violations := arch.ValidateDependenciesWithRules([]*arctest.DependencyRule{across})
+	if valid || len(violations) != 1 || !strings.Contains(violations[0], "shipping/infrastructure") {
+		t.Fatalf("Expected only the import of another subdomain to be reported, got %v", violations)
+	}
+	t.Logf("  ✓ %s", violations[0])
+
+	within, err := arch.DoesNotDependOnWithin(`^(?P<sd>[^/]+)/domain$`, `^(?P<sd>[^/]+)/infrastructure$`, "sd")
+	if err != nil {
+		t.Fatalf("Failed to create capture rule: %v", err)
+	}
+
+	valid, violations = arch.ValidateDependenciesWithRules([]*arctest.DependencyRule{within})
+	if valid || len(violations) != 1 || !strings.Contains(violations[0], "billing/infrastructure") {
+		t.Fatalf("Expected only the import of the same subdomain to be reported, got %v", violations)
+	}
+	t.Logf("  ✓ %s", violations[0])
+
+	if _, err := arch.DoesNotDependOnAcross(`^(?P<sd>[^/]+)/domain$`, `^infrastructure$`, "sd"); err == nil {
+		t.Errorf("Expected an error for a target pattern without the capture group")
+	}
+}
+
+// TestMatchByPackageName demonstrates how to match imports by package name rather than path
+func TestMatchByPackageName(t *testing.T) {
+	arch, err := arctest.NewFromFS(fstest.MapFS{
+		"go.mod":                   {Data: []byte("module example.com/shop\n\ngo 1.20\n")},
+		"billing/legacy/client.go": {Data: []byte("package legacy\n")},
+		"shipping/old/client.go":   {Data: []byte("package legacy\n")},
+		"shipping/client.go":       {Data: []byte("package shipping\n")},
+		"domain/order.go": {Data: []byte(`package domain
+
+import (
+	_ "example.com/shop/billing/legacy"
+	_ "example.com/shop/shipping"
+	_ "example.com/shop/shipping/old"
+	_ "github.com/acme/legacy/v2"
+)
+`)},
+	}, ".")
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	if err := arch.ParsePackages(); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	rule, err := arch.DoesNotDependOn("^domain$", "^legacy$")
+	if err != nil {
+		t.Fatalf("Failed to create dependency rule: %v", err)
+	}
+
+	if valid, violations := arch.ValidateDependenciesWithRules([]*arctest.DependencyRule{rule}); !valid {
+		t.Fatalf("Expected no import path to match, got %v", violations)
+	}
+
+	// The declared name of shipping/old and the path of the external module both match
+	rule.MatchBy = arctest.MatchByName
+	valid, violations := arch.ValidateDependenciesWithRules([]*arctest.DependencyRule{rule})
+	if valid || len(violations) != 3 {
+		t.Fatalf("Expected every package named legacy to be reported, got %v", violations)
+	}
+	for _, v := range violations {
+		t.Logf("  ✓ %s", v)
+	}
+}
@@ -0,0 +1,160 @@
+package examples
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/mstrYoda/go-arctest/pkg/arctest"
+)
+
+// TestExportDOT demonstrates how to render the package graph with Graphviz
+func TestExportDOT(t *testing.T) {
+	arch, err := arctest.New("./example_project")
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	if err := arch.ParsePackages("domain", "application", "utils"); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	domainLayer, err := arctest.NewLayer("Domain", "^domain$")
+	if err != nil {
+		t.Fatalf("Failed to create domain layer: %v", err)
+	}
+	layeredArch := arch.NewLayeredArchitecture(domainLayer)
+
+	var buf bytes.Buffer
+	if err := arch.ExportDOTWithLayers(&buf, layeredArch); err != nil {
+		t.Fatalf("Failed to export DOT graph: %v", err)
+	}
+
+	dot := buf.String()
+	for _, expected := range []string{
+		"digraph architecture {",
+		`"domain" -> "utils";`,
+		`"application/customer" -> "utils";`,
+		`tooltip="Domain"`,
+	} {
+		if !strings.Contains(dot, expected) {
+			t.Errorf("Expected DOT output to contain %q, got:\n%s", expected, dot)
+		}
+	}
+}
+
+// TestExportMermaid demonstrates how to document layers and their allowed dependencies in Markdown
+func TestExportMermaid(t *testing.T) {
+	arch, err := arctest.New("./example_project")
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	if err := arch.ParsePackages("domain", "application", "utils"); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	domainLayer, err := arctest.NewLayer("Domain", "^domain$")
+	if err != nil {
+		t.Fatalf("Failed to create domain layer: %v", err)
+	}
+	applicationLayer, err := arctest.NewLayer("Application", "^application$")
+	if err != nil {
+		t.Fatalf("Failed to create application layer: %v", err)
+	}
+	utilsLayer, err := arctest.NewLayer("Utils", "^utils$")
+	if err != nil {
+		t.Fatalf("Failed to create utils layer: %v", err)
+	}
+	layeredArch := arch.NewLayeredArchitecture(domainLayer, applicationLayer, utilsLayer)
+
+	if err := applicationLayer.DependsOnLayer(domainLayer); err != nil {
+		t.Fatalf("Failed to create layer dependency: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := layeredArch.ExportMermaid(&buf); err != nil {
+		t.Fatalf("Failed to export Mermaid diagram: %v", err)
+	}
+	expected := "graph TD\n" +
+		"    L0[\"Domain\"]\n" +
+		"    L1[\"Application\"]\n" +
+		"    L2[\"Utils\"]\n" +
+		"    L1 --> L0\n"
+	if buf.String() != expected {
+		t.Errorf("Unexpected diagram before checking:\n%s", buf.String())
+	}
+
+	// The dependencies on utils found by Check, including the one of application/customer, are drawn in red
+	if _, err := layeredArch.Check(); err != nil {
+		t.Fatalf("Failed to check layered architecture: %v", err)
+	}
+	buf.Reset()
+	if err := layeredArch.ExportMermaid(&buf); err != nil {
+		t.Fatalf("Failed to export Mermaid diagram: %v", err)
+	}
+	expected += "    L0 -->|violation| L2\n" +
+		"    L1 -->|violation| L2\n" +
+		"    linkStyle 1 stroke:red,stroke-width:2px\n" +
+		"    linkStyle 2 stroke:red,stroke-width:2px\n"
+	if buf.String() != expected {
+		t.Errorf("Unexpected diagram after checking:\n%s", buf.String())
+	}
+}
+
+// TestExportViolationGraphDOT demonstrates how to draw only the dependencies that violate the rules
+func TestExportViolationGraphDOT(t *testing.T) {
+	arch, err := arctest.New("./example_project")
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	if err := arch.ParsePackages("domain", "application", "utils"); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	domainLayer, err := arctest.NewLayer("Domain", "^domain$")
+	if err != nil {
+		t.Fatalf("Failed to create domain layer: %v", err)
+	}
+	applicationLayer, err := arctest.NewLayer("Application", "^application(/.*)?$")
+	if err != nil {
+		t.Fatalf("Failed to create application layer: %v", err)
+	}
+	utilsLayer, err := arctest.NewLayer("Utils", "^utils$")
+	if err != nil {
+		t.Fatalf("Failed to create utils layer: %v", err)
+	}
+	layeredArch := arch.NewLayeredArchitecture(domainLayer, applicationLayer, utilsLayer)
+
+	if err := applicationLayer.DependsOnLayer(domainLayer); err != nil {
+		t.Fatalf("Failed to create layer dependency: %v", err)
+	}
+
+	violations, err := layeredArch.CheckDetailed()
+	if err != nil {
+		t.Fatalf("Failed to check layered architecture: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := layeredArch.ExportViolationGraphDOT(violations, &buf); err != nil {
+		t.Fatalf("Failed to export violation graph: %v", err)
+	}
+
+	dot := buf.String()
+	for _, expected := range []string{
+		"digraph violations {",
+		`"domain" -> "utils" [color=red, label="1"];`,
+		`"application/customer" -> "utils" [color=red, label="1"];`,
+		`tooltip="Utils"`,
+	} {
+		if !strings.Contains(dot, expected) {
+			t.Errorf("Expected violation graph to contain %q, got:\n%s", expected, dot)
+		}
+	}
+
+	// The allowed dependency of application on domain is left out
+	if strings.Contains(dot, `"application" [`) {
+		t.Errorf("Expected packages without violations to be omitted, got:\n%s", dot)
+	}
+}
@@ -0,0 +1,100 @@
+package examples
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/mstrYoda/go-arctest/pkg/arctest"
+)
+
+// duplicateTypesFS declares UserRepository in two packages, of which the adapter
+// only implements the legacy one
+var duplicateTypesFS = fstest.MapFS{
+	"go.mod": {Data: []byte("module example.com/shop\n\ngo 1.20\n")},
+	"domain/user.go": {Data: []byte(`package domain
+
+// UserRepository stores users
+type UserRepository interface {
+	Save(id string) error
+}
+`)},
+	"legacy/user.go": {Data: []byte(`package legacy
+
+// UserRepository stores users the old way
+type UserRepository interface {
+	Store(id string) error
+}
+`)},
+	"infrastructure/user_repository.go": {Data: []byte(`package infrastructure
+
+// PostgresUserRepository only implements the legacy interface
+type PostgresUserRepository struct{}
+
+// Store stores a user
+func (r *PostgresUserRepository) Store(id string) error {
+	return nil
+}
+`)},
+}
+
+// TestDuplicateTypeNames demonstrates how to find and disambiguate types declared in several packages
+func TestDuplicateTypeNames(t *testing.T) {
+	arch, err := arctest.NewFromFS(duplicateTypesFS, ".")
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	if err := arch.ParsePackages(); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	duplicates := arch.DuplicateTypeNames()
+	if len(duplicates) != 1 || strings.Join(duplicates["UserRepository"], ",") != "domain,legacy" {
+		t.Fatalf("Expected UserRepository to be declared in domain and legacy, got %v", duplicates)
+	}
+
+	rule, err := arch.StructsImplementInterfaces("Repository$", "^UserRepository$")
+	if err != nil {
+		t.Fatalf("Failed to create interface rule: %v", err)
+	}
+
+	// The legacy interface satisfies the rule, so the check itself stays clean
+	violations, err := arch.CheckStructImplementsInterfaces([]*arctest.InterfaceImplementationRule{rule})
+	if err != nil {
+		t.Fatalf("Failed to check interface implementations: %v", err)
+	}
+	if len(violations) != 0 {
+		t.Fatalf("Expected no violations, got %v", violations)
+	}
+
+	// The ambiguity is reported separately as a warning
+	warnings := arch.InterfaceRuleWarnings([]*arctest.InterfaceImplementationRule{rule})
+	if len(warnings) != 1 || !warnings[0].IsWarning() || !strings.Contains(warnings[0].Message, "domain, legacy") {
+		t.Fatalf("Expected a single warning about the ambiguous interface name, got %v", warnings)
+	}
+	t.Logf("  ✓ %s", warnings[0])
+}
+
+// TestQualifiedNames demonstrates how fully-qualified names select the intended type
+func TestQualifiedNames(t *testing.T) {
+	arch, err := arctest.NewFromFS(duplicateTypesFS, ".", arctest.WithQualifiedNames(true))
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	if err := arch.ParsePackages(); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	rule, err := arch.StructsImplementInterfaces(`^infrastructure\..*Repository$`, `^domain\.UserRepository$`)
+	if err != nil {
+		t.Fatalf("Failed to create interface rule: %v", err)
+	}
+
+	valid, violations := arch.ValidateInterfaceImplementations([]*arctest.InterfaceImplementationRule{rule})
+	if valid || len(violations) != 1 || !strings.Contains(violations[0], "PostgresUserRepository") {
+		t.Fatalf("Expected the adapter to be reported for not implementing the domain interface, got %v", violations)
+	}
+	t.Logf("  ✓ %s", violations[0])
+}
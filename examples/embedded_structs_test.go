@@ -0,0 +1,46 @@
+package examples
+
+import (
+	"testing"
+
+	"github.com/mstrYoda/go-arctest/pkg/arctest"
+)
+
+// TestEmbeddedStructs verifies that methods promoted from embedded structs and interfaces
+// count towards interface implementations
+func TestEmbeddedStructs(t *testing.T) {
+	arch, err := arctest.New("./example_project")
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	if err := arch.ParsePackages("domain", "infrastructure"); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	cached := arch.GetPackage("infrastructure").Structs["CachedUserRepository"]
+	if len(cached.Embeds) != 1 || cached.Embeds[0] != "*userLookup" {
+		t.Errorf("Expected CachedUserRepository to embed *userLookup, got %v", cached.Embeds)
+	}
+
+	implementations, err := arch.FindAllImplementationsWithReceivers("UserRepositoryInterface", "domain")
+	if err != nil {
+		t.Fatalf("Failed to find implementations: %v", err)
+	}
+
+	kinds := map[string]arctest.ImplementationKind{}
+	for _, impl := range implementations {
+		kinds[impl.Struct.Name] = impl.Kind
+	}
+
+	// Embedding *userLookup promotes its pointer methods to the value type as well
+	if kinds["CachedUserRepository"] != arctest.ValueImplements {
+		t.Errorf("Expected CachedUserRepository to implement the interface by value, got %s", kinds["CachedUserRepository"])
+	}
+	if kinds["AuditedUserRepository"] != arctest.ValueImplements {
+		t.Errorf("Expected AuditedUserRepository to implement the interface by value, got %s", kinds["AuditedUserRepository"])
+	}
+	if _, found := kinds["userLookup"]; found {
+		t.Error("Expected userLookup not to implement the interface")
+	}
+}
@@ -0,0 +1,23 @@
+package domain
+
+import "errors"
+
+// UserStatus represents the lifecycle state of a user
+type UserStatus int
+
+// User lifecycle states
+const (
+	UserStatusPending UserStatus = iota
+	UserStatusActive
+	UserStatusSuspended
+	UserStatusDeleted
+)
+
+// Errors returned by user operations
+var (
+	ErrUserNotFound         = errors.New("user not found")
+	ErrUserExists           = errors.New("user already exists")
+	ErrInvalidEmail   error = errors.New("invalid email")
+	defaultStatus     UserStatus
+	maxUsernameLength = 32
+)
@@ -0,0 +1,62 @@
+package infrastructure
+
+import (
+	"errors"
+
+	"github.com/mstrYoda/go-arctest/examples/example_project/domain"
+)
+
+// userLookup provides the read operations shared by repositories
+type userLookup struct {
+	users map[string]*domain.User
+}
+
+// FindByID retrieves a user by their ID
+func (l *userLookup) FindByID(id string) (*domain.User, error) {
+	user, found := l.users[id]
+	if !found {
+		return nil, errors.New("user not found")
+	}
+	return user, nil
+}
+
+// FindByUsername retrieves a user by their username
+func (l *userLookup) FindByUsername(username string) (*domain.User, error) {
+	for _, user := range l.users {
+		if user.Username == username {
+			return user, nil
+		}
+	}
+	return nil, errors.New("user not found")
+}
+
+// CachedUserRepository implements domain.UserRepositoryInterface by embedding the
+// shared lookup methods and adding the write operations
+type CachedUserRepository struct {
+	*userLookup
+}
+
+// Save stores a user in the cache
+func (r CachedUserRepository) Save(user *domain.User) error {
+	r.users[user.ID] = user
+	return nil
+}
+
+// Delete removes a user from the cache
+func (r CachedUserRepository) Delete(id string) error {
+	delete(r.users, id)
+	return nil
+}
+
+// AuditedUserRepository implements domain.UserRepositoryInterface by embedding another
+// implementation of it
+type AuditedUserRepository struct {
+	domain.UserRepositoryInterface
+	audit []string
+}
+
+// NewAuditedUserRepository wraps a repository with auditing. It returns the interface
+// instead of the concrete struct, which goes against "accept interfaces, return structs".
+func NewAuditedUserRepository(repo domain.UserRepositoryInterface) domain.UserRepositoryInterface {
+	return &AuditedUserRepository{UserRepositoryInterface: repo}
+}
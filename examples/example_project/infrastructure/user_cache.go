@@ -0,0 +1,44 @@
+package infrastructure
+
+import (
+	"errors"
+
+	"github.com/mstrYoda/go-arctest/examples/example_project/domain"
+)
+
+// UserCache looks like a user repository, but FindByID only reports whether the
+// user is cached instead of returning it, so it does not satisfy
+// domain.UserRepositoryInterface
+type UserCache struct {
+	users map[string]*domain.User
+}
+
+// FindByID checks whether a user with the given ID is cached
+func (c *UserCache) FindByID(id string) error {
+	if _, found := c.users[id]; !found {
+		return errors.New("user not cached")
+	}
+	return nil
+}
+
+// FindByUsername retrieves a cached user by their username
+func (c *UserCache) FindByUsername(username string) (*domain.User, error) {
+	for _, user := range c.users {
+		if user.Username == username {
+			return user, nil
+		}
+	}
+	return nil, errors.New("user not cached")
+}
+
+// Save caches a user
+func (c *UserCache) Save(user *domain.User) error {
+	c.users[user.ID] = user
+	return nil
+}
+
+// Delete evicts a user from the cache
+func (c *UserCache) Delete(id string) error {
+	delete(c.users, id)
+	return nil
+}
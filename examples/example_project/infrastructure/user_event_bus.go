@@ -0,0 +1,26 @@
+package infrastructure
+
+import "github.com/mstrYoda/go-arctest/examples/example_project/domain"
+
+// UserEventBus distributes user changes to subscribers
+type UserEventBus struct {
+	subscribers map[string][]func(user *domain.User) error
+	events      chan *domain.User
+}
+
+// Subscribe registers a handler for the given topic
+func (b *UserEventBus) Subscribe(topic string, handler func(user *domain.User) error) {
+	b.subscribers[topic] = append(b.subscribers[topic], handler)
+}
+
+// Publish sends the given users to all subscribers
+func (b *UserEventBus) Publish(users ...*domain.User) {
+	for _, user := range users {
+		b.events <- user
+	}
+}
+
+// Events returns a channel on which published users can be received
+func (b *UserEventBus) Events() <-chan *domain.User {
+	return b.events
+}
@@ -0,0 +1,128 @@
+package examples
+
+import (
+	"sort"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/mstrYoda/go-arctest/pkg/arctest"
+)
+
+// TestExcludePackages demonstrates how to keep packages such as generated code out of the checks
+func TestExcludePackages(t *testing.T) {
+	arch, err := arctest.New("./example_project", arctest.WithExclude("^utils$"))
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	if err := arch.ParsePackages(); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	if _, ok := arch.Packages["utils"]; ok {
+		t.Fatalf("Expected the excluded utils package not to be parsed")
+	}
+
+	// Imports of the excluded package are still resolved
+	if pkgPath, ok := arch.ResolveImport(arch.ImportPath("utils")); !ok || pkgPath != "utils" {
+		t.Errorf("Expected the excluded package to be resolvable, got %q", pkgPath)
+	}
+
+	domainLayer, err := arctest.NewLayer("Domain", "^domain$")
+	if err != nil {
+		t.Fatalf("Failed to create domain layer: %v", err)
+	}
+
+	utilsLayer, err := arctest.NewLayer("Utils", "^utils$")
+	if err != nil {
+		t.Fatalf("Failed to create utils layer: %v", err)
+	}
+
+	layeredArch := arch.NewLayeredArchitecture(domainLayer, utilsLayer)
+
+	// The excluded package can still be the target of a violation
+	violations, err := layeredArch.Check()
+	if err != nil {
+		t.Fatalf("Failed to check layered architecture: %v", err)
+	}
+
+	found := false
+	for _, violation := range violations {
+		if strings.Contains(violation, "Domain") && strings.Contains(violation, "Utils") {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected the domain -> utils dependency to be reported, got %v", violations)
+	}
+}
+
+// TestLayerExcluding demonstrates how to remove packages from a single layer
+func TestLayerExcluding(t *testing.T) {
+	arch, err := arctest.New("./example_project")
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	if err := arch.ParsePackages("application", "application/customer"); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	applicationLayer, err := arctest.NewLayer("Application", "^application$")
+	if err != nil {
+		t.Fatalf("Failed to create application layer: %v", err)
+	}
+
+	if _, err := applicationLayer.Excluding("^application/customer$"); err != nil {
+		t.Fatalf("Failed to exclude packages: %v", err)
+	}
+
+	if !applicationLayer.Contains("application") {
+		t.Errorf("Expected the application package to belong to the layer")
+	}
+	if applicationLayer.Contains("application/customer") {
+		t.Errorf("Expected the excluded subpackage not to belong to the layer")
+	}
+
+	if _, err := arctest.New("./example_project", arctest.WithExclude("[")); err == nil {
+		t.Errorf("Expected an error for an invalid exclude pattern")
+	}
+}
+
+// TestSkipDirs demonstrates how to keep fixture and generated directories from being walked
+func TestSkipDirs(t *testing.T) {
+	fsys := fstest.MapFS{
+		"go.mod":                          {Data: []byte("module example.com/skip\n\ngo 1.20\n")},
+		"service/service.go":              {Data: []byte("package service\n")},
+		"service/testdata/broken/main.go": {Data: []byte("package broken\n\nfunc {")},
+		"service/mocks/store.go":          {Data: []byte("package mocks\n")},
+		"internal/gen/api/api.go":         {Data: []byte("package api\n")},
+		"internal/auth/auth.go":           {Data: []byte("package auth\n")},
+		"gen/gen.go":                      {Data: []byte("package gen\n")},
+	}
+
+	for _, parse := range []func(*arctest.Architecture) error{
+		func(arch *arctest.Architecture) error { return arch.ParsePackages() },
+		func(arch *arctest.Architecture) error { return arch.ParsePackages("service", "internal", "gen") },
+	} {
+		arch, err := arctest.NewFromFS(fsys, ".", arctest.WithSkipDirs("testdata", "mocks", "internal/gen"))
+		if err != nil {
+			t.Fatalf("Failed to create architecture: %v", err)
+		}
+
+		// The syntax error in testdata would fail parsing if the directory was walked
+		if err := parse(arch); err != nil {
+			t.Fatalf("Failed to parse packages: %v", err)
+		}
+
+		pkgPaths := []string{}
+		for pkgPath := range arch.Packages {
+			pkgPaths = append(pkgPaths, pkgPath)
+		}
+		sort.Strings(pkgPaths)
+		if strings.Join(pkgPaths, ",") != "gen,internal/auth,service" {
+			t.Errorf("Expected only gen, internal/auth and service to be parsed, got %v", pkgPaths)
+		}
+	}
+}
@@ -0,0 +1,208 @@
+package examples
+
+import (
+	"go/build"
+	"strings"
+	"testing"
+
+	"github.com/mstrYoda/go-arctest/pkg/arctest"
+)
+
+// TestMayOnlyImportExternal demonstrates how to restrict a layer to the standard library,
+// its own module and an allow-list of external modules
+func TestMayOnlyImportExternal(t *testing.T) {
+	arch, err := arctest.New("./testdata/external")
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	if err := arch.ParsePackages(); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	domainLayer, err := arctest.NewLayer("Domain", "^domain$")
+	if err != nil {
+		t.Fatalf("Failed to create domain layer: %v", err)
+	}
+
+	rule := domainLayer.MayOnlyImportExternal([]string{"github.com/google/uuid"})
+
+	// Standard library packages such as net/http, the module's own packages and
+	// allowed modules are accepted, anything else is reported
+	valid, violations := arch.ValidateExternalImports([]*arctest.ExternalImportRule{rule})
+	if valid {
+		t.Fatal("Expected external import violations, but none were found!")
+	}
+	if len(violations) != 1 || !strings.Contains(violations[0], `"golang.org/x/text/language"`) {
+		t.Fatalf("Expected only golang.org/x/text/language to be reported, got %v", violations)
+	}
+	t.Logf("  ✓ %s", violations[0])
+}
+
+// TestStandardLibraryImports verifies that standard library imports are never assigned to
+// a layer, even if a layer is named like a standard library package
+func TestStandardLibraryImports(t *testing.T) {
+	for _, importPath := range []string{"fmt", "net/http", "encoding/json", "path/filepath"} {
+		if !arctest.IsStandardLibrary(importPath) {
+			t.Errorf("Expected %q to be part of the standard library", importPath)
+		}
+	}
+	for _, importPath := range []string{"github.com/google/uuid", "example.com/external/http", "domain"} {
+		if arctest.IsStandardLibrary(importPath) {
+			t.Errorf("Expected %q not to be part of the standard library", importPath)
+		}
+	}
+
+	// Without GOROOT, a generated list of standard library packages is used instead
+	goroot := build.Default.GOROOT
+	build.Default.GOROOT = ""
+	if !arctest.IsStandardLibrary("hash/maphash") || arctest.IsStandardLibrary("shop/maphash") {
+		t.Errorf("Expected the generated list to tell standard library packages from dotless module paths")
+	}
+	build.Default.GOROOT = goroot
+
+	arch, err := arctest.New("./testdata/external")
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	if err := arch.ParsePackages(); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	domainLayer, err := arctest.NewLayer("Domain", "^domain$")
+	if err != nil {
+		t.Fatalf("Failed to create domain layer: %v", err)
+	}
+
+	httpLayer, err := arctest.NewLayer("HTTP", "^http$")
+	if err != nil {
+		t.Fatalf("Failed to create http layer: %v", err)
+	}
+
+	// The domain imports net/http, which must not be mistaken for the HTTP layer
+	violations, err := arch.NewLayeredArchitecture(domainLayer, httpLayer).Check()
+	if err != nil {
+		t.Fatalf("Failed to check layered architecture: %v", err)
+	}
+	if len(violations) != 0 {
+		t.Errorf("Expected no violations, got %v", violations)
+	}
+}
+
+// TestMustNotImportModule demonstrates how to forbid a layer from importing a specific module
+func TestMustNotImportModule(t *testing.T) {
+	arch, err := arctest.New("./testdata/external")
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	if err := arch.ParsePackages(); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	domainLayer, err := arctest.NewLayer("Domain", "^domain$")
+	if err != nil {
+		t.Fatalf("Failed to create domain layer: %v", err)
+	}
+	arch.NewLayeredArchitecture(domainLayer)
+
+	rules := []*arctest.DependencyRule{}
+	for _, module := range []string{"golang.org/x/text", "net/http", "github.com/google/uu"} {
+		rule, err := domainLayer.MustNotImportModule(module)
+		if err != nil {
+			t.Fatalf("Failed to create dependency rule: %v", err)
+		}
+		rules = append(rules, rule)
+	}
+
+	// The partial module path github.com/google/uu must not match github.com/google/uuid
+	valid, violations := arch.ValidateDependenciesWithRulesDetailed(rules)
+	if valid || len(violations) != 2 {
+		t.Fatalf("Expected exactly two dependency violations, got %v", violations)
+	}
+	for _, v := range violations {
+		if v.TargetPackage != "golang.org/x/text/language" && v.TargetPackage != "net/http" {
+			t.Errorf("Unexpected violation: %s", v)
+		}
+		t.Logf("  ✓ %s", v)
+	}
+}
+
+// TestExternalImports demonstrates how to list the third-party packages each package uses
+func TestExternalImports(t *testing.T) {
+	arch, err := arctest.New("./testdata/external")
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	if err := arch.ParsePackages(); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	external := arch.ExternalImports()
+	if len(external) != len(arch.Packages) {
+		t.Fatalf("Expected an entry for each of the %d packages, got %v", len(arch.Packages), external)
+	}
+
+	// Standard library and module imports are left out
+	if got := strings.Join(external["domain"], ","); got != "github.com/google/uuid,golang.org/x/text/language" {
+		t.Errorf("Unexpected external imports of domain: %v", external["domain"])
+	}
+	if len(external["http"]) != 0 || len(external["shared"]) != 0 {
+		t.Errorf("Expected http and shared not to import external packages, got %v", external)
+	}
+}
+
+// TestMustBeSelfContained demonstrates how to keep a layer free of imports of other packages
+func TestMustBeSelfContained(t *testing.T) {
+	arch, err := arctest.New("./testdata/external")
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	if err := arch.ParsePackages(); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	sharedLayer, err := arctest.NewLayer("Shared", "^shared$")
+	if err != nil {
+		t.Fatalf("Failed to create shared layer: %v", err)
+	}
+
+	domainLayer, err := arctest.NewLayer("Domain", "^domain$")
+	if err != nil {
+		t.Fatalf("Failed to create domain layer: %v", err)
+	}
+
+	arch.NewLayeredArchitecture(sharedLayer, domainLayer)
+
+	violations, err := sharedLayer.MustBeSelfContainedStrict()
+	if err != nil {
+		t.Fatalf("Failed to check layer: %v", err)
+	}
+	if len(violations) != 0 {
+		t.Errorf("Expected the shared layer to be self-contained, got %v", violations)
+	}
+
+	// The domain imports shared, github.com/google/uuid and golang.org/x/text/language
+	// besides three standard library packages
+	violations, err = domainLayer.MustBeSelfContained()
+	if err != nil {
+		t.Fatalf("Failed to check layer: %v", err)
+	}
+	if len(violations) != 3 {
+		t.Fatalf("Expected three non-standard imports to be reported, got %v", violations)
+	}
+	for _, v := range violations {
+		t.Logf("  ✓ %s", v)
+	}
+
+	violations, err = domainLayer.MustBeSelfContainedStrict()
+	if err != nil {
+		t.Fatalf("Failed to check layer: %v", err)
+	}
+	if len(violations) != 6 {
+		t.Errorf("Expected standard library imports to be reported as well, got %v", violations)
+	}
+}
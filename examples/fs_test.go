@@ -0,0 +1,97 @@
+package examples
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/mstrYoda/go-arctest/pkg/arctest"
+)
+
+// TestNewFromFS demonstrates how to analyze source code that isn't on disk
+func TestNewFromFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"go.mod": {Data: []byte("module example.com/memory\n\ngo 1.20\n")},
+		"service/domain/user.go": {Data: []byte(`package domain
+
+// User is a user of the system
+type User struct {
+	ID string
+}
+`)},
+		"service/application/users.go": {Data: []byte(`package application
+
+import "example.com/memory/service/domain"
+
+// Users manages users
+type Users struct {
+	byID map[string]*domain.User
+}
+`)},
+	}
+
+	arch, err := arctest.NewFromFS(fsys, "service")
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	if err := arch.ParsePackages(); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	if arch.ModulePath != "example.com/memory" {
+		t.Errorf("Expected the module path to be read from the file system, got %q", arch.ModulePath)
+	}
+	if len(arch.Packages) != 2 || arch.GetPackage("domain") == nil || arch.GetPackage("application") == nil {
+		t.Fatalf("Expected the domain and application packages to be parsed, got %v", arch.Packages)
+	}
+
+	rule, err := arch.DoesNotDependOn("^application$", ".*/domain$")
+	if err != nil {
+		t.Fatalf("Failed to create dependency rule: %v", err)
+	}
+
+	valid, violations := arch.ValidateDependenciesWithRulesDetailed([]*arctest.DependencyRule{rule})
+	if valid || len(violations) != 1 {
+		t.Fatalf("Expected exactly one dependency violation, got %v", violations)
+	}
+	if violations[0].File != "service/application/users.go" || violations[0].Line != 3 {
+		t.Errorf("Expected the violation to point at the import, got %s:%d", violations[0].File, violations[0].Line)
+	}
+}
+
+// TestStrictPaths verifies that parsing a path without Go packages is reported as an error
+func TestStrictPaths(t *testing.T) {
+	fsys := fstest.MapFS{
+		"go.mod":            {Data: []byte("module example.com/strict\n\ngo 1.20\n")},
+		"domain/user.go":    {Data: []byte("package domain\n")},
+		"docs/README.md":    {Data: []byte("# Docs\n")},
+		"domian/.gitkeep":   {Data: []byte{}},
+		"domain/sub/doc.go": {Data: []byte("package sub\n")},
+	}
+
+	arch, err := arctest.NewFromFS(fsys, ".")
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+	if err := arch.ParsePackages("domain"); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	// A typo that happens to name an existing directory must not go unnoticed
+	for _, path := range []string{"docs", "domian"} {
+		if err := arch.ParsePackages(path); err == nil {
+			t.Errorf("Expected an error for %s, which contains no Go packages", path)
+		}
+	}
+
+	arch, err = arctest.NewFromFS(fsys, ".", arctest.WithStrictPaths(false))
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+	if err := arch.ParsePackages("domain", "docs"); err != nil {
+		t.Fatalf("Expected empty paths to be accepted without strict paths, got %v", err)
+	}
+	if len(arch.Packages) != 2 {
+		t.Errorf("Expected domain and domain/sub to be parsed, got %v", arch.Packages)
+	}
+}
@@ -0,0 +1,44 @@
+package examples
+
+import (
+	"testing"
+
+	"github.com/mstrYoda/go-arctest/pkg/arctest"
+)
+
+// TestGlobLayers demonstrates how to define layers with glob patterns instead of regexes
+func TestGlobLayers(t *testing.T) {
+	arch, err := arctest.New("./example_project")
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	if err := arch.ParsePackages("domain", "application", "utils"); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	applicationLayer, err := arctest.NewLayerWithPatternType("Application", arctest.PatternGlob, "**/application/**")
+	if err != nil {
+		t.Fatalf("Failed to create application layer: %v", err)
+	}
+
+	topLevelLayer, err := arctest.NewLayerWithPatternType("TopLevel", arctest.PatternGlob, "*")
+	if err != nil {
+		t.Fatalf("Failed to create top level layer: %v", err)
+	}
+
+	for pkgPath, expected := range map[string]bool{
+		"application":          true,
+		"application/customer": true,
+		"domain":               false,
+	} {
+		if applicationLayer.Contains(pkgPath) != expected {
+			t.Errorf("Expected Application layer to contain %q: %v", pkgPath, expected)
+		}
+	}
+
+	// A single "*" only matches within one path segment
+	if !topLevelLayer.Contains("domain") || topLevelLayer.Contains("application/customer") {
+		t.Error("Expected \"*\" to match top level packages only")
+	}
+}
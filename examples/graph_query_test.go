@@ -0,0 +1,81 @@
+package examples
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mstrYoda/go-arctest/pkg/arctest"
+)
+
+// TestDependencyQueries demonstrates how to query the package graph directly
+func TestDependencyQueries(t *testing.T) {
+	arch, err := arctest.New("./example_project")
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	if err := arch.ParsePackages(); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	if got, want := arch.Dependents("domain"), []string{"application", "infrastructure"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected dependents %v of domain, got %v", want, got)
+	}
+	if got, want := arch.Dependencies("domain"), []string{"utils"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected dependencies %v of domain, got %v", want, got)
+	}
+
+	// Packages can also be given by their import path
+	if got, want := arch.Dependencies(arch.ImportPath("application/customer")), []string{"utils"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected dependencies %v of application/customer, got %v", want, got)
+	}
+
+	if got := arch.Dependents("presentation"); len(got) != 0 {
+		t.Errorf("Expected presentation to have no dependents, got %v", got)
+	}
+	if got := arch.Dependencies("unknown"); got == nil || len(got) != 0 {
+		t.Errorf("Expected an empty slice for an unknown package, got %v", got)
+	}
+}
+
+// TestCheckCoupling demonstrates how to flag packages with too many dependents or dependencies
+func TestCheckCoupling(t *testing.T) {
+	arch, err := arctest.New("./example_project")
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	if err := arch.ParsePackages(); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	// domain and utils are both imported by two packages
+	violations := arch.CheckCoupling(1, -1)
+	if len(violations) != 2 || !strings.Contains(violations[0], `"domain"`) || !strings.Contains(violations[1], `"utils"`) {
+		t.Fatalf("Expected domain and utils to exceed the dependents threshold, got %v", violations)
+	}
+	for _, v := range violations {
+		t.Logf("  ✓ %s", v)
+	}
+
+	// Every package of the example project imports at most one other package
+	if violations := arch.CheckCoupling(-1, 1); len(violations) != 0 {
+		t.Errorf("Expected no package to exceed the dependencies threshold, got %v", violations)
+	}
+	if violations := arch.CheckCoupling(2, 2); len(violations) != 0 {
+		t.Errorf("Expected no violations with generous thresholds, got %v", violations)
+	}
+	// Structured violations can be added to a suite as they are
+	suite, err := arctest.NewSuite("./example_project")
+	if err != nil {
+		t.Fatalf("Failed to create suite: %v", err)
+	}
+	recorder := &recordingT{}
+	suite.AddViolationCheck("Coupling", func() []arctest.Violation {
+		return suite.Arch.CheckCouplingDetailed(1, -1)
+	}).Run(recorder)
+	if len(recorder.errors) != 1 || !strings.Contains(recorder.errors[0], "2 architecture violation(s):\nCoupling:") {
+		t.Errorf("Expected the suite to report both coupling violations, got %v", recorder.errors)
+	}
+}
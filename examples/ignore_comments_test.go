@@ -0,0 +1,141 @@
+package examples
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/mstrYoda/go-arctest/pkg/arctest"
+)
+
+// TestIgnoreComments demonstrates how to accept single violations with //arctest:ignore comments
+func TestIgnoreComments(t *testing.T) {
+	arch, err := arctest.NewFromFS(fstest.MapFS{
+		"go.mod":               {Data: []byte("module example.com/shop\n\ngo 1.20\n")},
+		"infrastructure/db.go": {Data: []byte("package infrastructure\n")},
+		"domain/user.go": {Data: []byte(`package domain
+
+import _ "example.com/shop/infrastructure" //arctest:ignore layered registers the legacy driver
+`)},
+		"domain/order.go": {Data: []byte(`package domain
+
+import (
+	//arctest:ignore layer,dependency
+	_ "example.com/shop/infrastructure"
+)
+`)},
+		"domain/invoice.go": {Data: []byte(`package domain
+
+import _ "example.com/shop/infrastructure" //arctest:ignore dependency
+`)},
+		"domain/legacy.go": {Data: []byte(`//arctest:ignore
+
+package domain
+
+import _ "example.com/shop/infrastructure"
+`)},
+		"domain/payment.go": {Data: []byte(`package domain
+
+import _ "example.com/shop/infrastructure"
+`)},
+	}, ".")
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	if err := arch.ParsePackages(); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	domainLayer, err := arctest.NewLayer("Domain", "^domain$")
+	if err != nil {
+		t.Fatalf("Failed to create domain layer: %v", err)
+	}
+	infrastructureLayer, err := arctest.NewLayer("Infrastructure", "^infrastructure$")
+	if err != nil {
+		t.Fatalf("Failed to create infrastructure layer: %v", err)
+	}
+	layeredArch := arch.NewLayeredArchitecture(domainLayer, infrastructureLayer)
+	layeredArch.SetPolicyMode(arctest.DenyByDefault)
+
+	violations, err := layeredArch.CheckDetailed()
+	if err != nil {
+		t.Fatalf("Failed to check layered architecture: %v", err)
+	}
+	if len(violations) != 5 {
+		t.Fatalf("Expected every import to be reported without filtering, got %v", violations)
+	}
+
+	// The comment for dependency rules doesn't apply to layer violations
+	kept, suppressed := arch.FilterIgnored(violations)
+	if suppressed != 3 || len(kept) != 2 {
+		t.Fatalf("Expected three suppressed violations, got %d and kept %v", suppressed, kept)
+	}
+	for _, v := range kept {
+		if !strings.HasSuffix(v.File, "invoice.go") && !strings.HasSuffix(v.File, "payment.go") {
+			t.Errorf("Unexpected violation: %s", v)
+		}
+		t.Logf("  ✓ %s", v)
+	}
+
+	// Assertions filter suppressed violations automatically
+	recorder := &recordingT{}
+	arctest.Assert(recorder, layeredArch).HasNoViolations()
+	if len(recorder.errors) != 2 {
+		t.Errorf("Expected the assertion to report two violations, got %v", recorder.errors)
+	}
+	if len(recorder.logs) != 1 || !strings.Contains(recorder.logs[0], "3 architecture violation(s) suppressed") {
+		t.Errorf("Expected the suppressed violations to be counted, got %v", recorder.logs)
+	}
+
+	// Suites filter them as well, while the detailed check above returned all of them
+	suite := &arctest.Suite{Arch: arch}
+	recorder = &recordingT{}
+	suite.AddLayeredArchitecture(layeredArch).Run(recorder)
+	if len(recorder.errors) != 1 || !strings.Contains(recorder.errors[0], "2 architecture violation(s)") {
+		t.Errorf("Expected the suite to report two violations, got %v", recorder.errors)
+	}
+	if stats := suite.Stats(); len(stats) != 1 || stats[0].Suppressed != 3 {
+		t.Errorf("Expected the suite to count three suppressed violations, got %+v", stats)
+	}
+}
+
+// TestUnknownIgnoreRuleType verifies that misspelled rule types in ignore comments are
+// reported as warnings and suppress nothing
+func TestUnknownIgnoreRuleType(t *testing.T) {
+	arch, err := arctest.NewFromFS(fstest.MapFS{
+		"go.mod":               {Data: []byte("module example.com/shop\n\ngo 1.20\n")},
+		"infrastructure/db.go": {Data: []byte("package infrastructure\n")},
+		"domain/user.go": {Data: []byte(`package domain
+
+import _ "example.com/shop/infrastructure" //arctest:ignore layr
+`)},
+	}, ".")
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	if err := arch.ParsePackages(); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	warnings := arch.IgnoreWarnings()
+	if len(warnings) != 1 || !warnings[0].IsWarning() || warnings[0].Line != 3 || !strings.Contains(warnings[0].Message, `Unknown rule type "layr"`) {
+		t.Fatalf("Expected the misspelled rule type to be reported, got %v", warnings)
+	}
+	t.Logf("  ✓ %s", warnings[0])
+
+	rule, err := arch.DoesNotDependOn("^domain$", "^infrastructure$")
+	if err != nil {
+		t.Fatalf("Failed to create dependency rule: %v", err)
+	}
+	suite := &arctest.Suite{Arch: arch}
+	recorder := &recordingT{}
+	suite.AddDependencyRules(rule).Run(recorder)
+	if len(recorder.errors) != 1 {
+		t.Errorf("Expected the import to be reported despite the comment, got %v", recorder.errors)
+	}
+	if len(recorder.logs) != 1 || !strings.Contains(recorder.logs[0], `Unknown rule type "layr"`) {
+		t.Errorf("Expected the suite to log the misspelled rule type, got %v", recorder.logs)
+	}
+}
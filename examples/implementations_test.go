@@ -0,0 +1,289 @@
+package examples
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/mstrYoda/go-arctest/pkg/arctest"
+)
+
+// TestInterfaceMustBeImplemented demonstrates how to find interfaces without enough implementations
+func TestInterfaceMustBeImplemented(t *testing.T) {
+	arch, err := arctest.NewFromFS(repositoriesFS, ".")
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	if err := arch.ParsePackages(); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	violations, err := arch.InterfaceMustBeImplemented("Repository$", 1)
+	if err != nil {
+		t.Fatalf("Failed to check interface implementations: %v", err)
+	}
+	if len(violations) != 0 {
+		t.Errorf("Expected OrderRepository to be implemented, got %v", violations)
+	}
+
+	// Only the PostgreSQL repository implements OrderRepository
+	violations, err = arch.InterfaceMustBeImplemented("Repository$", 2)
+	if err != nil {
+		t.Fatalf("Failed to check interface implementations: %v", err)
+	}
+	if len(violations) != 1 || !strings.Contains(violations[0], "has 1 implementation(s)") {
+		t.Fatalf("Expected OrderRepository to lack a second implementation, got %v", violations)
+	}
+	t.Logf("  ✓ %s", violations[0])
+
+	if _, err := arch.InterfaceMustBeImplemented("(", 1); err == nil {
+		t.Error("Expected an error for an invalid interface pattern")
+	}
+}
+
+// TestInterfaceMustBeImplementedQualified verifies that interface patterns match qualified names with QualifiedNames set
+func TestInterfaceMustBeImplementedQualified(t *testing.T) {
+	arch, err := arctest.NewFromFS(repositoriesFS, ".", arctest.WithQualifiedNames(true))
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	if err := arch.ParsePackages(); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	violations, err := arch.InterfaceMustBeImplemented(`^domain\.OrderRepository$`, 2)
+	if err != nil {
+		t.Fatalf("Failed to check interface implementations: %v", err)
+	}
+	if len(violations) != 1 || !strings.Contains(violations[0], `Interface "OrderRepository" in package "domain"`) {
+		t.Fatalf("Expected domain.OrderRepository to be matched by its qualified name, got %v", violations)
+	}
+	t.Logf("  ✓ %s", violations[0])
+
+	// Bare names no longer match patterns anchored at the start
+	if violations, err := arch.InterfaceMustBeImplemented("^OrderRepository$", 2); err != nil || len(violations) != 0 {
+		t.Errorf("Expected the bare name pattern to match nothing, got %v (%v)", violations, err)
+	}
+}
+
+// TestMustContainAllImplementationsOf demonstrates how to keep the adapters of a port in one layer
+func TestMustContainAllImplementationsOf(t *testing.T) {
+	arch, err := arctest.New("./example_project")
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	if err := arch.ParsePackages(); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	infrastructureLayer, err := arctest.NewLayer("Infrastructure", "^infrastructure$")
+	if err != nil {
+		t.Fatalf("Failed to create infrastructure layer: %v", err)
+	}
+
+	applicationLayer, err := arctest.NewLayer("Application", "^application$")
+	if err != nil {
+		t.Fatalf("Failed to create application layer: %v", err)
+	}
+
+	arch.NewLayeredArchitecture(infrastructureLayer, applicationLayer)
+
+	violations, err := infrastructureLayer.MustContainAllImplementationsOf("^UserRepositoryInterface$")
+	if err != nil {
+		t.Fatalf("Failed to check implementations: %v", err)
+	}
+	if len(violations) != 0 {
+		t.Errorf("Expected all repositories to be in the infrastructure layer, got %v", violations)
+	}
+
+	// The same implementations are misplaced from the point of view of the application layer
+	violations, err = applicationLayer.MustContainAllImplementationsOf("^UserRepositoryInterface$")
+	if err != nil {
+		t.Fatalf("Failed to check implementations: %v", err)
+	}
+	if len(violations) == 0 {
+		t.Fatal("Expected implementations outside the application layer to be reported")
+	}
+	for _, v := range violations {
+		if !strings.Contains(v, `in package "infrastructure"`) {
+			t.Errorf("Unexpected violation: %s", v)
+		}
+		t.Logf("  ✓ %s", v)
+	}
+
+	// With qualified names, the pattern selects the interface by its package as well
+	qualifiedArch, err := arctest.New("./example_project", arctest.WithQualifiedNames(true))
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+	if err := qualifiedArch.ParsePackages(); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+	qualifiedApplication, err := arctest.NewLayer("Application", "^application$")
+	if err != nil {
+		t.Fatalf("Failed to create application layer: %v", err)
+	}
+	qualifiedArch.NewLayeredArchitecture(qualifiedApplication)
+
+	qualified, err := qualifiedApplication.MustContainAllImplementationsOf(`^domain\.UserRepositoryInterface$`)
+	if err != nil {
+		t.Fatalf("Failed to check implementations: %v", err)
+	}
+	if len(qualified) != len(violations) {
+		t.Errorf("Expected the qualified pattern to report %d implementations, got %v", len(violations), qualified)
+	}
+}
+
+// TestMarkerInterfacesAreSkipped verifies that interfaces without methods don't make every struct an implementation
+func TestMarkerInterfacesAreSkipped(t *testing.T) {
+	arch, err := arctest.NewFromFS(fstest.MapFS{
+		"go.mod": {Data: []byte("module example.com/shop\n\ngo 1.20\n")},
+		"domain/marker.go": {Data: []byte(`package domain
+
+// Marker tags domain types
+type Marker interface{}
+`)},
+		"application/service.go": {Data: []byte(`package application
+
+// Service runs use cases
+type Service struct{}
+`)},
+	}, ".")
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	if err := arch.ParsePackages(); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	infrastructureLayer, err := arctest.NewLayer("Infrastructure", "^infrastructure$")
+	if err != nil {
+		t.Fatalf("Failed to create infrastructure layer: %v", err)
+	}
+	arch.NewLayeredArchitecture(infrastructureLayer)
+
+	violations, err := infrastructureLayer.MustContainAllImplementationsOf(".*")
+	if err != nil {
+		t.Fatalf("Failed to check implementations: %v", err)
+	}
+	if len(violations) != 0 {
+		t.Errorf("Expected the marker interface to be skipped, got %v", violations)
+	}
+}
+
+// TestAssertDependencyInversion demonstrates how to check that adapters live in an outer layer
+func TestAssertDependencyInversion(t *testing.T) {
+	arch, err := arctest.New("./example_project")
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	if err := arch.ParsePackages(); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	domainLayer, err := arctest.NewLayer("Domain", "^domain$")
+	if err != nil {
+		t.Fatalf("Failed to create domain layer: %v", err)
+	}
+
+	infrastructureLayer, err := arctest.NewLayer("Infrastructure", "^infrastructure$")
+	if err != nil {
+		t.Fatalf("Failed to create infrastructure layer: %v", err)
+	}
+
+	layeredArch := arch.NewLayeredArchitecture(domainLayer, infrastructureLayer)
+
+	// The repositories implementing domain.UserRepositoryInterface are infrastructure, but
+	// utils.Logger implementing domain.Logger is not
+	violations, err := layeredArch.AssertDependencyInversion("Domain", "Infrastructure")
+	if err != nil {
+		t.Fatalf("Failed to check dependency inversion: %v", err)
+	}
+	if len(violations) != 1 || !strings.Contains(violations[0], `Struct "Logger" in package "utils"`) {
+		t.Fatalf("Expected only the utils logger to be reported, got %v", violations)
+	}
+	t.Logf("  ✓ %s", violations[0])
+
+	if _, err := layeredArch.AssertDependencyInversion("Domain", "Adapters"); err == nil {
+		t.Errorf("Expected an error for an unknown layer")
+	}
+}
+
+// TestRankImplementations demonstrates how to find the structs closest to implementing an interface
+func TestRankImplementations(t *testing.T) {
+	arch, err := arctest.NewFromFS(fstest.MapFS{
+		"go.mod": {Data: []byte("module example.com/shop\n\ngo 1.20\n")},
+		"domain/store.go": {Data: []byte(`package domain
+
+// Store stores and loads orders
+type Store interface {
+	Save() error
+	Load() error
+	Delete() error
+}
+`)},
+		"infrastructure/stores.go": {Data: []byte(`package infrastructure
+
+// FullStore implements every method
+type FullStore struct{}
+
+func (s *FullStore) Save() error   { return nil }
+func (s *FullStore) Load() error   { return nil }
+func (s *FullStore) Delete() error { return nil }
+
+// ReadWriteStore can't delete
+type ReadWriteStore struct{}
+
+func (s *ReadWriteStore) Save() error { return nil }
+func (s *ReadWriteStore) Load() error { return nil }
+
+// AppendStore can only save
+type AppendStore struct{}
+
+func (s *AppendStore) Save() error { return nil }
+
+// Clock has nothing in common with a store
+type Clock struct{}
+
+func (c *Clock) Now() int { return 0 }
+`)},
+	}, ".")
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	if err := arch.ParsePackages(); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	ranked := arch.RankImplementations("Store", "domain")
+	want := []struct {
+		name    string
+		matched int
+		missing string
+	}{
+		{"FullStore", 3, ""},
+		{"ReadWriteStore", 2, "Delete"},
+		{"AppendStore", 1, "Load,Delete"},
+	}
+	if len(ranked) != len(want) {
+		t.Fatalf("Expected %d ranked structs without the clock, got %d", len(want), len(ranked))
+	}
+	for idx, w := range want {
+		match := ranked[idx]
+		if match.Struct.Name != w.name || match.MatchedMethods != w.matched || strings.Join(match.MissingMethods, ",") != w.missing {
+			t.Errorf("Expected %s to be ranked at %d with %d matched methods and missing %q, got %s with %d missing %v",
+				w.name, idx, w.matched, w.missing, match.Struct.Name, match.MatchedMethods, match.MissingMethods)
+		}
+		t.Logf("  ✓ %s: %d matched, missing %v", match.Struct.Name, match.MatchedMethods, match.MissingMethods)
+	}
+
+	if ranked := arch.RankImplementations("Missing", "domain"); ranked != nil {
+		t.Errorf("Expected nil for an unknown interface, got %v", ranked)
+	}
+}
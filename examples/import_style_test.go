@@ -0,0 +1,93 @@
+package examples
+
+import (
+	"sort"
+	"testing"
+	"testing/fstest"
+
+	"github.com/mstrYoda/go-arctest/pkg/arctest"
+)
+
+// importStyleProject uses dot and blank imports in several packages
+var importStyleProject = fstest.MapFS{
+	"go.mod": {Data: []byte("module example.com/app\n\ngo 1.20\n")},
+	"cmd/server/main.go": {Data: []byte(`package main
+
+import (
+	_ "github.com/lib/pq"
+
+	"example.com/app/store"
+)
+
+func main() {
+	store.Open()
+}
+`)},
+	"store/store.go": {Data: []byte(`package store
+
+import (
+	db "database/sql"
+	_ "github.com/lib/pq"
+)
+
+// Open opens the database
+func Open() (*db.DB, error) {
+	return db.Open("postgres", "")
+}
+`)},
+	"names/names.go": {Data: []byte(`package names
+
+import . "strings"
+
+// Normalize normalizes a name
+func Normalize(name string) string {
+	return ToLower(TrimSpace(name))
+}
+`)},
+}
+
+// TestImportStyle demonstrates how to forbid dot-imports and restrict blank imports
+func TestImportStyle(t *testing.T) {
+	arch, err := arctest.NewFromFS(importStyleProject, ".")
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	if err := arch.ParsePackages(); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	// The parser keeps track of how each package is imported
+	kinds := map[string]arctest.ImportKind{}
+	for _, spec := range arch.GetPackage("store").ImportSpecs {
+		kinds[spec.Path] = spec.Kind
+	}
+	if kinds["database/sql"] != arctest.ImportNamed || kinds["github.com/lib/pq"] != arctest.ImportBlank {
+		t.Errorf("Unexpected import kinds: %v", kinds)
+	}
+
+	// Blank imports of database drivers are only allowed in main packages
+	rule, err := arctest.NewImportStyleRule(".*", true, true, "^main$")
+	if err != nil {
+		t.Fatalf("Failed to create import style rule: %v", err)
+	}
+
+	violations, err := arch.CheckImportStyle([]*arctest.ImportStyleRule{rule})
+	if err != nil {
+		t.Fatalf("Failed to check import style: %v", err)
+	}
+	sort.Strings(violations)
+
+	expected := []string{
+		`names/names.go:3:8: Package "names" dot-imports "strings", which is not allowed`,
+		`store/store.go:5:2: Package "store" blank-imports "github.com/lib/pq", which is not allowed outside of the allowed packages`,
+	}
+	if len(violations) != len(expected) {
+		t.Fatalf("Expected %d violations, got %v", len(expected), violations)
+	}
+	for idx := range expected {
+		if violations[idx] != expected[idx] {
+			t.Errorf("Expected %q, got %q", expected[idx], violations[idx])
+		}
+	}
+}
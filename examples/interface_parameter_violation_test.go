@@ -1,7 +1,9 @@
 package examples
 
 import (
+	"strings"
 	"testing"
+	"testing/fstest"
 
 	"github.com/mstrYoda/go-arctest/pkg/arctest"
 )
@@ -63,3 +65,118 @@ func TestInterfaceParameterViolation(t *testing.T) {
 		}
 	}
 }
+
+// TestConstructorParameters demonstrates how to apply parameter rules to constructors
+func TestConstructorParameters(t *testing.T) {
+	arch, err := arctest.New("./example_project")
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	if err := arch.ParsePackages(); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	// No struct is named "^$", so only package-level functions are checked
+	rule, err := arctest.NewParameterRule("^$", "^New", ".*", true)
+	if err != nil {
+		t.Fatalf("Failed to create parameter rule: %v", err)
+	}
+
+	violations, err := arch.CheckMethodParameters([]*arctest.ParameterRule{rule})
+	if err != nil {
+		t.Fatalf("Failed to check method parameters: %v", err)
+	}
+	if len(violations) != 0 {
+		t.Fatalf("Expected free functions to be ignored by default, got %v", violations)
+	}
+
+	// NewUserHandler takes *application.UserService, while NewUserService takes an interface
+	rule.IncludeFreeFunctions = true
+	violations, err = arch.CheckMethodParameters([]*arctest.ParameterRule{rule})
+	if err != nil {
+		t.Fatalf("Failed to check method parameters: %v", err)
+	}
+	if len(violations) != 1 || !strings.Contains(violations[0], `Function "NewUserHandler"`) {
+		t.Fatalf("Expected only NewUserHandler to be reported, got %v", violations)
+	}
+	t.Logf("  ✓ %s", violations[0])
+}
+
+// TestAliasedParameterTypes demonstrates that parameter types are resolved through import aliases
+func TestAliasedParameterTypes(t *testing.T) {
+	arch, err := arctest.NewFromFS(fstest.MapFS{
+		"go.mod": {Data: []byte("module mymod\n\ngo 1.20\n")},
+		"domain/user.go": {Data: []byte(`package domain
+
+// User is a user of the system
+type User struct {
+	ID string
+}
+
+// Notifier notifies users
+type Notifier interface {
+	Notify(user *User) error
+}
+`)},
+		"models/notifier.go": {Data: []byte(`package models
+
+// Notifier is a concrete notifier sharing its name with the domain interface
+type Notifier struct{}
+`)},
+		"application/service.go": {Data: []byte(`package application
+
+import (
+	d "mymod/domain"
+	domain "mymod/models"
+)
+
+// UserService handles users
+type UserService struct{}
+
+// Register registers a user
+func (s *UserService) Register(user *d.User) error {
+	return nil
+}
+
+// Notify notifies a user
+func (s *UserService) Notify(notifier *domain.Notifier) error {
+	return nil
+}
+`)},
+	}, ".")
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	if err := arch.ParsePackages(); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	rule, err := arctest.NewParameterRule("Service$", ".*", ".*", true)
+	if err != nil {
+		t.Fatalf("Failed to create parameter rule: %v", err)
+	}
+
+	// d.User is the domain struct, and domain.Notifier is the models struct rather than the
+	// domain interface of the same name
+	violations, err := arch.CheckMethodParameters([]*arctest.ParameterRule{rule})
+	if err != nil {
+		t.Fatalf("Failed to check method parameters: %v", err)
+	}
+	if len(violations) != 2 {
+		t.Fatalf("Expected both struct parameters to be reported, got %v", violations)
+	}
+	for _, want := range []string{`"d.User"`, `"domain.Notifier"`} {
+		found := false
+		for _, v := range violations {
+			if strings.Contains(v, want) {
+				found = true
+				t.Logf("  ✓ %s", v)
+			}
+		}
+		if !found {
+			t.Errorf("Expected a violation for %s, got %v", want, violations)
+		}
+	}
+}
@@ -0,0 +1,317 @@
+package examples
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/mstrYoda/go-arctest/pkg/arctest"
+)
+
+// repositoriesFS contains repositories in two packages, of which only the
+// infrastructure ones implement the domain interface
+var repositoriesFS = fstest.MapFS{
+	"go.mod": {Data: []byte("module example.com/shop\n\ngo 1.20\n")},
+	"domain/order.go": {Data: []byte(`package domain
+
+// Order is a customer order
+type Order struct {
+	ID string
+}
+
+// OrderRepository stores orders
+type OrderRepository interface {
+	Save(order *Order) error
+}
+`)},
+	"infrastructure/postgres/order_repository.go": {Data: []byte(`package postgres
+
+import "example.com/shop/domain"
+
+// OrderRepository stores orders in PostgreSQL
+type OrderRepository struct{}
+
+// Save stores an order
+func (r *OrderRepository) Save(order *domain.Order) error {
+	return nil
+}
+
+// BrokenOrderRepository forgot to implement Save
+type BrokenOrderRepository struct{}
+`)},
+	"testing/fakes/order_repository.go": {Data: []byte(`package fakes
+
+// RecordingOrderRepository records calls for tests and doesn't implement the interface
+type RecordingOrderRepository struct {
+	Calls int
+}
+`)},
+}
+
+// TestInterfaceRuleInPackages demonstrates how to scope an interface rule to structs of some packages
+func TestInterfaceRuleInPackages(t *testing.T) {
+	arch, err := arctest.NewFromFS(repositoriesFS, ".")
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	if err := arch.ParsePackages(); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	rule, err := arch.StructsImplementInterfaces("Repository$", "^OrderRepository$")
+	if err != nil {
+		t.Fatalf("Failed to create interface rule: %v", err)
+	}
+
+	violations, err := arch.CheckStructImplementsInterfaces([]*arctest.InterfaceImplementationRule{rule})
+	if err != nil {
+		t.Fatalf("Failed to check interface implementations: %v", err)
+	}
+	if len(violations) != 2 {
+		t.Fatalf("Expected the broken and the fake repository to be reported without a package pattern, got %v", violations)
+	}
+
+	for _, pattern := range []string{"^infrastructure/", "^example.com/shop/infrastructure/"} {
+		rule, err := arch.StructsImplementInterfaces("Repository$", "^OrderRepository$")
+		if err != nil {
+			t.Fatalf("Failed to create interface rule: %v", err)
+		}
+		if _, err := rule.InPackages(pattern); err != nil {
+			t.Fatalf("Failed to scope interface rule: %v", err)
+		}
+
+		violations, err := arch.CheckStructImplementsInterfaces([]*arctest.InterfaceImplementationRule{rule})
+		if err != nil {
+			t.Fatalf("Failed to check interface implementations: %v", err)
+		}
+		if len(violations) != 1 || !strings.Contains(violations[0], "BrokenOrderRepository") {
+			t.Fatalf("Expected only the broken repository to be reported for %q, got %v", pattern, violations)
+		}
+		t.Logf("  ✓ %s", violations[0])
+	}
+}
+
+// TestLayerScopedInterfaceRule demonstrates that layer-scoped interface rules only check structs of the layer
+func TestLayerScopedInterfaceRule(t *testing.T) {
+	arch, err := arctest.NewFromFS(repositoriesFS, ".")
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	if err := arch.ParsePackages(); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	infrastructureLayer, err := arctest.NewLayer("Infrastructure", "^infrastructure/.*$")
+	if err != nil {
+		t.Fatalf("Failed to create infrastructure layer: %v", err)
+	}
+
+	testingLayer, err := arctest.NewLayer("Testing", "^testing/.*$")
+	if err != nil {
+		t.Fatalf("Failed to create testing layer: %v", err)
+	}
+
+	arch.NewLayeredArchitecture(infrastructureLayer, testingLayer)
+
+	rule, err := infrastructureLayer.StructsImplementInterfaces("Repository$", "^OrderRepository$")
+	if err != nil {
+		t.Fatalf("Failed to create interface rule: %v", err)
+	}
+
+	valid, violations := arch.ValidateInterfaceImplementationsDetailed([]*arctest.InterfaceImplementationRule{rule})
+	if valid || len(violations) != 1 {
+		t.Fatalf("Expected exactly one violation within the infrastructure layer, got %v", violations)
+	}
+	if violations[0].SourcePackage != "infrastructure/postgres" || !strings.Contains(violations[0].Message, "BrokenOrderRepository") {
+		t.Errorf("Expected the broken repository to be reported, got %s", violations[0])
+	}
+	t.Logf("  ✓ %s", violations[0])
+
+	// The fake repository was ignored above because it lives outside the layer, but is
+	// reported once a rule is scoped to the layer it belongs to
+	rule, err = testingLayer.StructsImplementInterfaces("^Recording", "^OrderRepository$")
+	if err != nil {
+		t.Fatalf("Failed to create interface rule: %v", err)
+	}
+	if valid, violations := arch.ValidateInterfaceImplementations([]*arctest.InterfaceImplementationRule{rule}); valid {
+		t.Errorf("Expected the fake repository to be reported within the testing layer")
+	} else {
+		t.Logf("  ✓ %s", violations[0])
+	}
+}
+
+// TestExportedOnlyRules demonstrates how to skip unexported helper structs
+func TestExportedOnlyRules(t *testing.T) {
+	arch, err := arctest.NewFromFS(fstest.MapFS{
+		"go.mod": {Data: []byte("module example.com/shop\n\ngo 1.20\n")},
+		"domain/order.go": {Data: []byte(`package domain
+
+// Entity is implemented by every exported domain type
+type Entity interface {
+	ID() string
+}
+
+// Order is a customer order
+type Order struct{}
+
+// ID returns the identifier of the order
+func (o *Order) ID() string {
+	return ""
+}
+
+// Invoice is missing its identifier
+type Invoice struct{}
+
+// orderBuilder is an internal helper
+type orderBuilder struct{}
+
+// add adds an order to the builder
+func (b *orderBuilder) add(order *Order) {}
+`)},
+	}, ".")
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	if err := arch.ParsePackages(); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	rule, err := arch.StructsImplementInterfaces(".*", "^Entity$")
+	if err != nil {
+		t.Fatalf("Failed to create interface rule: %v", err)
+	}
+
+	if _, violations := arch.ValidateInterfaceImplementations([]*arctest.InterfaceImplementationRule{rule}); len(violations) != 2 {
+		t.Fatalf("Expected the invoice and the builder to be reported, got %v", violations)
+	}
+
+	rule.ExportedOnly = true
+	_, violations := arch.ValidateInterfaceImplementations([]*arctest.InterfaceImplementationRule{rule})
+	if len(violations) != 1 || !strings.Contains(violations[0], `"Invoice"`) {
+		t.Fatalf("Expected only the invoice to be reported, got %v", violations)
+	}
+	t.Logf("  ✓ %s", violations[0])
+
+	parameterRule, err := arch.MethodsShouldUseInterfaceParameters(".*", ".*", ".*")
+	if err != nil {
+		t.Fatalf("Failed to create parameter rule: %v", err)
+	}
+	parameterRule.ExportedOnly = true
+	if valid, violations := arch.ValidateMethodParameters([]*arctest.ParameterRule{parameterRule}); !valid {
+		t.Errorf("Expected the unexported builder to be skipped, got %v", violations)
+	}
+}
+
+// TestStructsMustImplement demonstrates how to check structs against one specific interface
+func TestStructsMustImplement(t *testing.T) {
+	arch, err := arctest.NewFromFS(repositoriesFS, ".")
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	if err := arch.ParsePackages(); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	violations, err := arch.StructsMustImplement("Repository$", "OrderRepository", "domain")
+	if err != nil {
+		t.Fatalf("Failed to check structs: %v", err)
+	}
+	if len(violations) != 2 {
+		t.Fatalf("Expected the broken and the fake repository to be reported, got %v", violations)
+	}
+	for _, v := range violations {
+		if !strings.Contains(v, "Save (missing)") {
+			t.Errorf("Expected the missing method to be reported, got %s", v)
+		}
+		t.Logf("  ✓ %s", v)
+	}
+
+	if _, err := arch.StructsMustImplement("Repository$", "Missing", "domain"); err == nil {
+		t.Errorf("Expected an error for an unknown interface")
+	}
+}
+
+// TestInterfaceRuleFromPackage demonstrates how to tie an interface rule to the interfaces of one package
+func TestInterfaceRuleFromPackage(t *testing.T) {
+	arch, err := arctest.NewFromFS(fstest.MapFS{
+		"go.mod": {Data: []byte("module example.com/shop\n\ngo 1.20\n")},
+		"v1/domain/user.go": {Data: []byte(`package domain
+
+// User is a customer of the first API version
+type User struct{}
+
+// UserRepository stores users
+type UserRepository interface {
+	Save(user *User) error
+}
+`)},
+		"v2/domain/user.go": {Data: []byte(`package domain
+
+// User is a customer of the second API version
+type User struct{}
+
+// UserRepository stores users
+type UserRepository interface {
+	Save(user *User) error
+}
+`)},
+		"infrastructure/postgres/user_repository.go": {Data: []byte(`package postgres
+
+import "example.com/shop/v2/domain"
+
+// UserRepository stores users of the second API version in PostgreSQL
+type UserRepository struct{}
+
+// Save stores a user
+func (r *UserRepository) Save(user *domain.User) error {
+	return nil
+}
+`)},
+	}, ".")
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	if err := arch.ParsePackages(); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	repository := arch.GetPackage("infrastructure/postgres").Structs["UserRepository"]
+	v1 := arch.GetPackage("v1/domain").Interfaces["UserRepository"]
+	v2 := arch.GetPackage("v2/domain").Interfaces["UserRepository"]
+
+	// By package name, both versions of domain.User look the same
+	if implements, _ := arctest.CheckInterfaceImplementation(repository, v1); !implements {
+		t.Errorf("Expected the repository to implement the first version by name")
+	}
+	if implements, missing := arctest.CheckExactInterfaceImplementation(repository, v1); implements || len(missing) != 1 {
+		t.Errorf("Expected Save to differ from the first version, got %v", missing)
+	}
+	if implements, missing := arctest.CheckExactInterfaceImplementation(repository, v2); !implements {
+		t.Errorf("Expected the repository to implement the second version, missing %v", missing)
+	}
+
+	for pkgPath, wantValid := range map[string]bool{
+		"v1/domain":                  false,
+		"example.com/shop/v2/domain": true,
+		"v3/domain":                  false,
+	} {
+		rule, err := arch.StructsImplementInterfaces("Repository$", "^UserRepository$")
+		if err != nil {
+			t.Fatalf("Failed to create interface rule: %v", err)
+		}
+		rule.FromPackage(pkgPath)
+
+		valid, violations := arch.ValidateInterfaceImplementations([]*arctest.InterfaceImplementationRule{rule})
+		if valid != wantValid {
+			t.Errorf("Expected valid to be %t for interfaces of %s, got %v", wantValid, pkgPath, violations)
+		}
+		for _, v := range violations {
+			t.Logf("  ✓ %s", v)
+		}
+	}
+}
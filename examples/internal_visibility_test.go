@@ -0,0 +1,58 @@
+package examples
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/mstrYoda/go-arctest/pkg/arctest"
+)
+
+// TestInternalVisibility demonstrates how imports of internal packages from outside their tree are reported
+func TestInternalVisibility(t *testing.T) {
+	fsys := fstest.MapFS{
+		"go.mod":                    {Data: []byte("module example.com/shop\n\ngo 1.20\n")},
+		"internal/config/config.go": {Data: []byte("package config\n")},
+		"billing/billing.go": {Data: []byte(`package billing
+
+import _ "example.com/shop/billing/internal/rates"
+`)},
+		"billing/internal/rates/rates.go": {Data: []byte("package rates\n")},
+		"billing/invoice/invoice.go": {Data: []byte(`package invoice
+
+import _ "example.com/shop/billing/internal/rates"
+`)},
+		"orders/orders.go": {Data: []byte(`package orders
+
+import (
+	_ "example.com/shop/billing/internal/rates"
+	_ "example.com/shop/internal/config"
+	_ "internal/cpu"
+)
+`)},
+	}
+
+	arch, err := arctest.NewFromFS(fsys, ".")
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	if err := arch.ParsePackages(); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	// Only orders reaches into the billing tree and the standard library's internals
+	violations := arch.CheckInternalVisibility()
+	if len(violations) != 2 {
+		t.Fatalf("Expected exactly two internal visibility violations, got %v", violations)
+	}
+	if !strings.Contains(violations[0], `"example.com/shop/billing/internal/rates"`) || !strings.Contains(violations[0], `within "example.com/shop/billing"`) {
+		t.Errorf("Expected the billing internal import to be reported, got %s", violations[0])
+	}
+	if !strings.Contains(violations[1], `"internal/cpu"`) {
+		t.Errorf("Expected the standard library internal import to be reported, got %s", violations[1])
+	}
+	for _, v := range violations {
+		t.Logf("  ✓ %s", v)
+	}
+}
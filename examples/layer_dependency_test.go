@@ -1,6 +1,7 @@
 package examples
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/mstrYoda/go-arctest/pkg/arctest"
@@ -136,3 +137,61 @@ func TestLayerDependencyRules(t *testing.T) {
 		}
 	}
 }
+
+// TestCheckChanged demonstrates how to only check the packages touched by a change
+func TestCheckChanged(t *testing.T) {
+	arch, err := arctest.New("./example_project")
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	if err := arch.ParsePackages(); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	domainLayer, err := arctest.NewLayer("Domain", "^domain$")
+	if err != nil {
+		t.Fatalf("Failed to create domain layer: %v", err)
+	}
+	applicationLayer, err := arctest.NewLayer("Application", "^application$")
+	if err != nil {
+		t.Fatalf("Failed to create application layer: %v", err)
+	}
+	utilsLayer, err := arctest.NewLayer("Utils", "^utils$")
+	if err != nil {
+		t.Fatalf("Failed to create utils layer: %v", err)
+	}
+	layeredArch := arch.NewLayeredArchitecture(domainLayer, applicationLayer, utilsLayer)
+	if err := applicationLayer.DependsOnLayer(domainLayer); err != nil {
+		t.Fatalf("Failed to create layer dependency: %v", err)
+	}
+
+	// Both domain and application/customer import utils, but only the domain changed
+	violations, err := layeredArch.CheckChanged([]string{"domain"})
+	if err != nil {
+		t.Fatalf("Failed to check layered architecture: %v", err)
+	}
+	if len(violations) != 1 || !strings.Contains(violations[0], `Package "domain"`) {
+		t.Fatalf("Expected only the domain violation to be reported, got %v", violations)
+	}
+	t.Logf("  ✓ %s", violations[0])
+
+	violations, err = layeredArch.CheckChanged([]string{arch.ImportPath("application/customer"), "presentation"})
+	if err != nil {
+		t.Fatalf("Failed to check layered architecture: %v", err)
+	}
+	if len(violations) != 1 || !strings.Contains(violations[0], `Package "application/customer"`) {
+		t.Fatalf("Expected only the application/customer violation to be reported, got %v", violations)
+	}
+
+	rule, err := arch.DoesNotDependOn(".*", ".*/utils$")
+	if err != nil {
+		t.Fatalf("Failed to create dependency rule: %v", err)
+	}
+	if violations := arch.CheckChanged([]string{"infrastructure"}, rule); len(violations) != 0 {
+		t.Errorf("Expected no violations for the infrastructure package, got %v", violations)
+	}
+	if violations := arch.CheckChanged([]string{"domain"}, rule); len(violations) != 1 {
+		t.Errorf("Expected one violation for the domain package, got %v", violations)
+	}
+}
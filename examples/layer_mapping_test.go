@@ -0,0 +1,704 @@
+package examples
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/mstrYoda/go-arctest/pkg/arctest"
+)
+
+// TestRequireAllPackagesMapped demonstrates how to catch packages that were not assigned to a layer
+func TestRequireAllPackagesMapped(t *testing.T) {
+	arch, err := arctest.New("./example_project")
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	if err := arch.ParsePackages("domain", "application", "utils"); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	domainLayer, err := arctest.NewLayer("Domain", "^domain$")
+	if err != nil {
+		t.Fatalf("Failed to create domain layer: %v", err)
+	}
+
+	applicationLayer, err := arctest.NewLayer("Application", "^application$")
+	if err != nil {
+		t.Fatalf("Failed to create application layer: %v", err)
+	}
+
+	// The utils package is intentionally left out of every layer
+	layeredArch := arch.NewLayeredArchitecture(domainLayer, applicationLayer)
+	layeredArch.RequireAllPackagesMapped(true)
+
+	violations, err := layeredArch.Check()
+	if err != nil {
+		t.Fatalf("Failed to check layered architecture: %v", err)
+	}
+
+	found := false
+	for _, violation := range violations {
+		if strings.Contains(violation, `Package "utils" does not belong to any layer`) {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected the unmapped utils package to be reported, got %v", violations)
+	}
+}
+
+// TestOverlappingLayers demonstrates how to detect packages claimed by more than one layer
+func TestOverlappingLayers(t *testing.T) {
+	arch, err := arctest.New("./example_project")
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	if err := arch.ParsePackages("domain", "application"); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	applicationLayer, err := arctest.NewLayer("Application", "^application$")
+	if err != nil {
+		t.Fatalf("Failed to create application layer: %v", err)
+	}
+
+	// This pattern accidentally overlaps with the application layer's subpackages
+	customerLayer, err := arctest.NewLayer("Customer", ".*/customer$")
+	if err != nil {
+		t.Fatalf("Failed to create customer layer: %v", err)
+	}
+
+	layeredArch := arch.NewLayeredArchitecture(applicationLayer, customerLayer)
+
+	problems := layeredArch.Validate()
+	if len(problems) != 1 || !strings.Contains(problems[0], `"application/customer" is matched by multiple layers: "Application", "Customer"`) {
+		t.Errorf("Expected application/customer to be reported as overlapping, got %v", problems)
+	}
+}
+
+// TestConflictingLayerRules demonstrates how to detect layer rules that contradict or repeat each other
+func TestConflictingLayerRules(t *testing.T) {
+	arch, err := arctest.New("./example_project")
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	if err := arch.ParsePackages("domain", "application", "presentation"); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	domainLayer, err := arctest.NewLayer("Domain", "^domain$")
+	if err != nil {
+		t.Fatalf("Failed to create domain layer: %v", err)
+	}
+	applicationLayer, err := arctest.NewLayer("Application", "^application$")
+	if err != nil {
+		t.Fatalf("Failed to create application layer: %v", err)
+	}
+	presentationLayer, err := arctest.NewLayer("Presentation", "^presentation$")
+	if err != nil {
+		t.Fatalf("Failed to create presentation layer: %v", err)
+	}
+	layeredArch := arch.NewLayeredArchitecture(domainLayer, applicationLayer, presentationLayer)
+
+	if problems := layeredArch.Validate(); len(problems) != 0 {
+		t.Fatalf("Expected no problems without rules, got %v", problems)
+	}
+
+	if err := applicationLayer.DependsOnLayer(domainLayer); err != nil {
+		t.Fatalf("Failed to create layer dependency: %v", err)
+	}
+	if err := presentationLayer.DependsOnLayer(applicationLayer); err != nil {
+		t.Fatalf("Failed to create layer dependency: %v", err)
+	}
+	// The forbidding rule silently wins over the allowing one
+	if err := applicationLayer.MustNotDependOnLayer(domainLayer); err != nil {
+		t.Fatalf("Failed to forbid layer dependency: %v", err)
+	}
+	if err := presentationLayer.DependsOnLayer(applicationLayer); err != nil {
+		t.Fatalf("Failed to create layer dependency: %v", err)
+	}
+
+	problems := layeredArch.Validate()
+	if len(problems) != 2 ||
+		!strings.Contains(problems[0], `Rules layer "Application" may depend on layer "Domain" and layer "Application" must not depend on layer "Domain" contradict each other`) ||
+		!strings.Contains(problems[1], `Rule layer "Presentation" may depend on layer "Application" is declared more than once`) {
+		t.Fatalf("Expected the contradicting and the repeated rule to be reported, got %v", problems)
+	}
+	for _, problem := range problems {
+		t.Logf("  ✓ %s", problem)
+	}
+}
+
+// TestAssertDisjoint demonstrates how to assert that two layers never share a package
+func TestAssertDisjoint(t *testing.T) {
+	arch, err := arctest.New("./example_project")
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	if err := arch.ParsePackages("domain", "application"); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	domainLayer, err := arctest.NewLayer("Domain", "^domain$")
+	if err != nil {
+		t.Fatalf("Failed to create domain layer: %v", err)
+	}
+	applicationLayer, err := arctest.NewLayer("Application", "^application$")
+	if err != nil {
+		t.Fatalf("Failed to create application layer: %v", err)
+	}
+	customerLayer, err := arctest.NewLayer("Customer", ".*/customer$")
+	if err != nil {
+		t.Fatalf("Failed to create customer layer: %v", err)
+	}
+
+	layeredArch := arch.NewLayeredArchitecture(domainLayer, applicationLayer, customerLayer)
+
+	if problems := layeredArch.AssertDisjoint("Domain", "Application"); len(problems) != 0 {
+		t.Errorf("Expected the domain and application layers to be disjoint, got %v", problems)
+	}
+
+	problems := layeredArch.AssertDisjoint("Application", "Customer")
+	if len(problems) != 1 || !strings.Contains(problems[0], `"application/customer" belongs to both layer "Application" and layer "Customer"`) {
+		t.Fatalf("Expected application/customer to be shared, got %v", problems)
+	}
+	t.Logf("  ✓ %s", problems[0])
+
+	if problems := layeredArch.AssertDisjoint("Domain", "Worker"); len(problems) != 1 {
+		t.Errorf("Expected an unknown layer to be reported, got %v", problems)
+	}
+}
+
+// TestMultiPatternLayer verifies that imports are attributed to a layer through any of its patterns
+func TestMultiPatternLayer(t *testing.T) {
+	arch, err := arctest.New("./example_project")
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	// Only the presentation layer is parsed, so its imports are matched by pattern alone
+	if err := arch.ParsePackages("presentation"); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	presentationLayer, err := arctest.NewLayer("Presentation", "^presentation$")
+	if err != nil {
+		t.Fatalf("Failed to create presentation layer: %v", err)
+	}
+
+	coreLayer, err := arctest.NewLayer("Core", "^domain$", "^application$")
+	if err != nil {
+		t.Fatalf("Failed to create core layer: %v", err)
+	}
+
+	layeredArch := arch.NewLayeredArchitecture(presentationLayer, coreLayer)
+
+	violations, err := layeredArch.Check()
+	if err != nil {
+		t.Fatalf("Failed to check layered architecture: %v", err)
+	}
+	if len(violations) != 1 || !strings.Contains(violations[0], `in layer "Core"`) {
+		t.Fatalf("Expected the application import to be attributed to the Core layer, got %v", violations)
+	}
+
+	if err := presentationLayer.DependsOnLayer(coreLayer); err != nil {
+		t.Fatalf("Failed to create layer dependency: %v", err)
+	}
+	violations, err = layeredArch.Check()
+	if err != nil {
+		t.Fatalf("Failed to check layered architecture: %v", err)
+	}
+	if len(violations) != 0 {
+		t.Errorf("Expected no violations once presentation may depend on Core, got %v", violations)
+	}
+}
+
+// TestPathStyleLayerPatterns demonstrates layers built from full import path patterns
+func TestPathStyleLayerPatterns(t *testing.T) {
+	arch, err := arctest.New("./example_project")
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	if err := arch.ParsePackages(); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	applicationLayer, err := arctest.NewLayer("Application", "^.*/application/.*$")
+	if err != nil {
+		t.Fatalf("Failed to create application layer: %v", err)
+	}
+
+	supportLayer, err := arctest.NewLayer("Support", "^.*/utils$", "^infrastructure$")
+	if err != nil {
+		t.Fatalf("Failed to create support layer: %v", err)
+	}
+
+	arch.NewLayeredArchitecture(applicationLayer, supportLayer)
+
+	rule, err := applicationLayer.DoesNotDependOnLayer(supportLayer)
+	if err != nil {
+		t.Fatalf("Failed to create dependency rule: %v", err)
+	}
+
+	violations, err := arch.CheckDependencies([]*arctest.DependencyRule{rule})
+	if err != nil {
+		t.Fatalf("Failed to check dependencies: %v", err)
+	}
+	if len(violations) != 1 || !strings.Contains(violations[0], "application/customer") {
+		t.Fatalf("Expected the utils import of application/customer to be reported, got %v", violations)
+	}
+	for _, v := range violations {
+		t.Logf("  ✓ %s", v)
+	}
+}
+
+// TestUnusedRules demonstrates how to find allow rules that no import needs anymore
+func TestUnusedRules(t *testing.T) {
+	arch, err := arctest.New("./example_project")
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	if err := arch.ParsePackages("domain", "application", "presentation"); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	domainLayer, err := arctest.NewLayer("Domain", "^domain$")
+	if err != nil {
+		t.Fatalf("Failed to create domain layer: %v", err)
+	}
+
+	applicationLayer, err := arctest.NewLayer("Application", "^application$")
+	if err != nil {
+		t.Fatalf("Failed to create application layer: %v", err)
+	}
+
+	presentationLayer, err := arctest.NewLayer("Presentation", "^presentation$")
+	if err != nil {
+		t.Fatalf("Failed to create presentation layer: %v", err)
+	}
+
+	layeredArch := arch.NewLayeredArchitecture(domainLayer, applicationLayer, presentationLayer)
+
+	if err := applicationLayer.DependsOnLayer(domainLayer); err != nil {
+		t.Fatalf("Failed to create layer dependency: %v", err)
+	}
+	if err := presentationLayer.DependsOnLayer(applicationLayer); err != nil {
+		t.Fatalf("Failed to create layer dependency: %v", err)
+	}
+	// The presentation layer doesn't import the domain layer directly
+	if err := presentationLayer.DependsOnLayer(domainLayer); err != nil {
+		t.Fatalf("Failed to create layer dependency: %v", err)
+	}
+
+	if _, err := layeredArch.Check(); err != nil {
+		t.Fatalf("Failed to check layered architecture: %v", err)
+	}
+
+	unused := layeredArch.UnusedRules()
+	if len(unused) != 1 || !strings.Contains(unused[0], `layer "Presentation" may depend on layer "Domain"`) {
+		t.Fatalf("Expected only the presentation -> domain rule to be unused, got %v", unused)
+	}
+	t.Logf("  ✓ %s", unused[0])
+}
+
+// TestUnusedRulesPerPolicy verifies that allow rules count as exercised whatever the policy,
+// and for composition roots as well
+func TestUnusedRulesPerPolicy(t *testing.T) {
+	arch, err := arctest.New("./example_project")
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	if err := arch.ParsePackages("domain", "application", "presentation"); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	cases := []struct {
+		name            string
+		policy          arctest.PolicyMode
+		compositionRoot bool
+	}{
+		{"deny by default", arctest.DenyByDefault, false},
+		{"allow by default", arctest.AllowByDefault, false},
+		{"composition root", arctest.DenyByDefault, true},
+	}
+	for _, c := range cases {
+		domainLayer, err := arctest.NewLayer("Domain", "^domain$")
+		if err != nil {
+			t.Fatalf("Failed to create domain layer: %v", err)
+		}
+		applicationLayer, err := arctest.NewLayer("Application", "^application$")
+		if err != nil {
+			t.Fatalf("Failed to create application layer: %v", err)
+		}
+		presentationLayer, err := arctest.NewLayer("Presentation", "^presentation$")
+		if err != nil {
+			t.Fatalf("Failed to create presentation layer: %v", err)
+		}
+		layeredArch := arch.NewLayeredArchitecture(domainLayer, applicationLayer, presentationLayer)
+
+		layeredArch.SetPolicyMode(c.policy)
+		if c.compositionRoot {
+			if err := layeredArch.MarkCompositionRoot("Application"); err != nil {
+				t.Fatalf("Failed to mark composition root: %v", err)
+			}
+		}
+
+		if err := applicationLayer.DependsOnLayer(domainLayer); err != nil {
+			t.Fatalf("Failed to create layer dependency: %v", err)
+		}
+		rule, err := arch.DependsOn("^presentation$", "^domain$")
+		if err != nil {
+			t.Fatalf("Failed to create dependency rule: %v", err)
+		}
+		layeredArch.AddDependencyConstraint(rule)
+		forbidden, err := arch.DoesNotDependOn("^domain$", "^presentation$")
+		if err != nil {
+			t.Fatalf("Failed to create dependency rule: %v", err)
+		}
+		layeredArch.AddDependencyConstraint(forbidden)
+
+		if _, err := layeredArch.Check(); err != nil {
+			t.Fatalf("Failed to check layered architecture: %v", err)
+		}
+
+		unused := layeredArch.UnusedRules()
+		if len(unused) != 1 || !strings.Contains(unused[0], "^presentation$ may depend on ^domain$") {
+			t.Errorf("%s: expected only the presentation -> domain rule to be unused, got %v", c.name, unused)
+			continue
+		}
+		t.Logf("  ✓ %s: %s", c.name, unused[0])
+	}
+}
+
+// TestAllowByDefaultPolicy demonstrates how to only enforce explicitly forbidden dependencies
+func TestAllowByDefaultPolicy(t *testing.T) {
+	arch, err := arctest.New("./example_project")
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	if err := arch.ParsePackages("domain", "application", "utils"); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	domainLayer, err := arctest.NewLayer("Domain", "^domain$")
+	if err != nil {
+		t.Fatalf("Failed to create domain layer: %v", err)
+	}
+
+	applicationLayer, err := arctest.NewLayer("Application", "^application$")
+	if err != nil {
+		t.Fatalf("Failed to create application layer: %v", err)
+	}
+
+	utilsLayer, err := arctest.NewLayer("Utils", "^utils$")
+	if err != nil {
+		t.Fatalf("Failed to create utils layer: %v", err)
+	}
+
+	layeredArch := arch.NewLayeredArchitecture(domainLayer, applicationLayer, utilsLayer)
+	layeredArch.SetPolicyMode(arctest.AllowByDefault)
+
+	// Without any rule, every dependency is allowed
+	violations, err := layeredArch.Check()
+	if err != nil {
+		t.Fatalf("Failed to check layered architecture: %v", err)
+	}
+	if len(violations) != 0 {
+		t.Fatalf("Expected no violations without rules, got %v", violations)
+	}
+
+	rule, err := domainLayer.DoesNotDependOnLayer(utilsLayer)
+	if err != nil {
+		t.Fatalf("Failed to create layer dependency rule: %v", err)
+	}
+	layeredArch.AddDependencyConstraint(rule)
+
+	violations, err = layeredArch.Check()
+	if err != nil {
+		t.Fatalf("Failed to check layered architecture: %v", err)
+	}
+	if len(violations) != 1 || !strings.Contains(violations[0], `in layer "Utils", but a rule forbids this dependency`) {
+		t.Fatalf("Expected only the forbidden domain -> utils dependency to be reported, got %v", violations)
+	}
+	t.Logf("  ✓ %s", violations[0])
+}
+
+// TestCompositionRoot demonstrates how to let main packages wire all layers together
+func TestCompositionRoot(t *testing.T) {
+	fsys := fstest.MapFS{
+		"go.mod":         {Data: []byte("module example.com/wiring\n\ngo 1.20\n")},
+		"domain/user.go": {Data: []byte("package domain\n")},
+		"postgres/users.go": {Data: []byte(`package postgres
+
+import _ "example.com/wiring/domain"
+`)},
+		"cmd/server/main.go": {Data: []byte(`package main
+
+import (
+	_ "example.com/wiring/domain"
+	_ "example.com/wiring/postgres"
+)
+`)},
+	}
+
+	arch, err := arctest.NewFromFS(fsys, ".")
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	if err := arch.ParsePackages(); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	domainLayer, err := arctest.NewLayer("Domain", "^domain$")
+	if err != nil {
+		t.Fatalf("Failed to create domain layer: %v", err)
+	}
+	postgresLayer, err := arctest.NewLayer("Postgres", "^postgres$")
+	if err != nil {
+		t.Fatalf("Failed to create postgres layer: %v", err)
+	}
+	cmdLayer, err := arctest.NewLayer("Cmd", "^cmd/.*$")
+	if err != nil {
+		t.Fatalf("Failed to create cmd layer: %v", err)
+	}
+
+	layeredArch := arch.NewLayeredArchitecture(domainLayer, postgresLayer, cmdLayer)
+	if err := postgresLayer.DependsOnLayer(domainLayer); err != nil {
+		t.Fatalf("Failed to create layer dependency: %v", err)
+	}
+
+	violations, err := layeredArch.Check()
+	if err != nil {
+		t.Fatalf("Failed to check layered architecture: %v", err)
+	}
+	if len(violations) != 2 {
+		t.Fatalf("Expected both imports of the main package to be reported, got %v", violations)
+	}
+
+	if err := layeredArch.MarkCompositionRoot("Cmd"); err != nil {
+		t.Fatalf("Failed to mark composition root: %v", err)
+	}
+	violations, err = layeredArch.Check()
+	if err != nil {
+		t.Fatalf("Failed to check layered architecture: %v", err)
+	}
+	if len(violations) != 0 {
+		t.Fatalf("Expected the composition root to import any layer, got %v", violations)
+	}
+
+	// Explicitly forbidden dependencies are still reported
+	rule, err := cmdLayer.DoesNotDependOnLayer(postgresLayer)
+	if err != nil {
+		t.Fatalf("Failed to create dependency rule: %v", err)
+	}
+	layeredArch.AddDependencyConstraint(rule)
+	violations, err = layeredArch.Check()
+	if err != nil {
+		t.Fatalf("Failed to check layered architecture: %v", err)
+	}
+	if len(violations) != 1 || !strings.Contains(violations[0], `layer "Postgres"`) {
+		t.Fatalf("Expected the forbidden postgres import to be reported, got %v", violations)
+	}
+	t.Logf("  ✓ %s", violations[0])
+
+	if err := layeredArch.MarkCompositionRoot("Unknown"); err == nil {
+		t.Error("Expected an error for an unknown layer")
+	}
+}
+
+// TestAllowTransitive demonstrates how chains of allowed dependencies imply the transitive ones
+func TestAllowTransitive(t *testing.T) {
+	arch, err := arctest.NewFromFS(fstest.MapFS{
+		"go.mod":         {Data: []byte("module example.com/shop\n\ngo 1.20\n")},
+		"domain/user.go": {Data: []byte("package domain\n")},
+		"application/service.go": {Data: []byte(`package application
+
+import _ "example.com/shop/domain"
+`)},
+		"presentation/handler.go": {Data: []byte(`package presentation
+
+import (
+	_ "example.com/shop/application"
+	_ "example.com/shop/domain"
+)
+`)},
+		"infrastructure/repository.go": {Data: []byte(`package infrastructure
+
+import (
+	_ "example.com/shop/application"
+	_ "example.com/shop/domain"
+)
+`)},
+	}, ".")
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	if err := arch.ParsePackages(); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	layers := map[string]*arctest.Layer{}
+	for _, name := range []string{"presentation", "application", "domain", "infrastructure"} {
+		layer, err := arctest.NewLayer(name, "^"+name+"$")
+		if err != nil {
+			t.Fatalf("Failed to create %s layer: %v", name, err)
+		}
+		layers[name] = layer
+	}
+	layeredArch := arch.NewLayeredArchitecture(layers["presentation"], layers["application"], layers["domain"], layers["infrastructure"])
+
+	for _, dependency := range [][2]string{{"presentation", "application"}, {"application", "domain"}, {"infrastructure", "domain"}} {
+		if err := layers[dependency[0]].DependsOnLayer(layers[dependency[1]]); err != nil {
+			t.Fatalf("Failed to create layer dependency: %v", err)
+		}
+	}
+
+	violations, err := layeredArch.Check()
+	if err != nil {
+		t.Fatalf("Failed to check layered architecture: %v", err)
+	}
+	if len(violations) != 2 {
+		t.Fatalf("Expected presentation -> domain and infrastructure -> application to be reported, got %v", violations)
+	}
+
+	// Infrastructure and application both depend on domain, which doesn't connect them
+	layeredArch.AllowTransitive(true)
+	violations, err = layeredArch.Check()
+	if err != nil {
+		t.Fatalf("Failed to check layered architecture: %v", err)
+	}
+	if len(violations) != 1 || !strings.Contains(violations[0], `Package "infrastructure"`) {
+		t.Fatalf("Expected only infrastructure -> application to be reported, got %v", violations)
+	}
+	t.Logf("  ✓ %s", violations[0])
+
+	// Explicitly forbidden dependencies are not implied
+	rule, err := layers["presentation"].DoesNotDependOnLayer(layers["domain"])
+	if err != nil {
+		t.Fatalf("Failed to create dependency rule: %v", err)
+	}
+	layeredArch.AddDependencyConstraint(rule)
+	violations, err = layeredArch.Check()
+	if err != nil {
+		t.Fatalf("Failed to check layered architecture: %v", err)
+	}
+	if len(violations) != 2 || !strings.Contains(strings.Join(violations, "\n"), "a rule forbids this dependency") {
+		t.Fatalf("Expected the forbidden presentation -> domain dependency to be reported, got %v", violations)
+	}
+}
+
+// TestMustNotDependOnLayer demonstrates how to forbid a dependency within the layered architecture
+func TestMustNotDependOnLayer(t *testing.T) {
+	arch, err := arctest.NewFromFS(fstest.MapFS{
+		"go.mod":         {Data: []byte("module example.com/shop\n\ngo 1.20\n")},
+		"domain/user.go": {Data: []byte("package domain\n")},
+		"application/service.go": {Data: []byte(`package application
+
+import _ "example.com/shop/domain"
+`)},
+		"presentation/handler.go": {Data: []byte(`package presentation
+
+import (
+	_ "example.com/shop/application"
+	_ "example.com/shop/domain"
+)
+`)},
+	}, ".")
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	if err := arch.ParsePackages(); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	domainLayer, err := arctest.NewLayer("Domain", "^domain$")
+	if err != nil {
+		t.Fatalf("Failed to create domain layer: %v", err)
+	}
+	applicationLayer, err := arctest.NewLayer("Application", "^application$")
+	if err != nil {
+		t.Fatalf("Failed to create application layer: %v", err)
+	}
+	presentationLayer, err := arctest.NewLayer("Presentation", "^presentation$")
+	if err != nil {
+		t.Fatalf("Failed to create presentation layer: %v", err)
+	}
+	layeredArch := arch.NewLayeredArchitecture(domainLayer, applicationLayer, presentationLayer)
+	layeredArch.SetPolicyMode(arctest.AllowByDefault)
+
+	if err := presentationLayer.MustNotDependOnLayer(domainLayer); err != nil {
+		t.Fatalf("Failed to forbid layer dependency: %v", err)
+	}
+
+	violations, err := layeredArch.Check()
+	if err != nil {
+		t.Fatalf("Failed to check layered architecture: %v", err)
+	}
+	if len(violations) != 1 || !strings.Contains(violations[0], `Package "presentation" in layer "Presentation" imports "example.com/shop/domain"`) {
+		t.Fatalf("Expected only presentation -> domain to be reported, got %v", violations)
+	}
+	t.Logf("  ✓ %s", violations[0])
+
+	// The forbidding rule takes precedence over allow rules in the default deny mode as well
+	layeredArch.SetPolicyMode(arctest.DenyByDefault)
+	for _, dependency := range [][2]*arctest.Layer{{applicationLayer, domainLayer}, {presentationLayer, applicationLayer}, {presentationLayer, domainLayer}} {
+		if err := dependency[0].DependsOnLayer(dependency[1]); err != nil {
+			t.Fatalf("Failed to create layer dependency: %v", err)
+		}
+	}
+	violations, err = layeredArch.Check()
+	if err != nil {
+		t.Fatalf("Failed to check layered architecture: %v", err)
+	}
+	if len(violations) != 1 || !strings.Contains(violations[0], "a rule forbids this dependency") {
+		t.Fatalf("Expected presentation -> domain to be reported despite the allow rule, got %v", violations)
+	}
+}
+
+// TestPackagesMustSharePrefix demonstrates how to check that the packages of a layer live below one directory
+func TestPackagesMustSharePrefix(t *testing.T) {
+	arch, err := arctest.NewFromFS(fstest.MapFS{
+		"go.mod":              {Data: []byte("module example.com/shop\n\ngo 1.20\n")},
+		"domain/domain.go":    {Data: []byte("package domain\n")},
+		"domain/user/user.go": {Data: []byte("package user\n")},
+		"domainx/x.go":        {Data: []byte("package domainx\n")},
+		"shared/domain/id.go": {Data: []byte("package domain\n")},
+	}, ".")
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	if err := arch.ParsePackages(); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	domainLayer, err := arctest.NewLayer("Domain", "domain")
+	if err != nil {
+		t.Fatalf("Failed to create domain layer: %v", err)
+	}
+	arch.NewLayeredArchitecture(domainLayer)
+
+	// The prefix covers the domain package itself but not domainx, with or without a trailing slash
+	for _, prefix := range []string{"domain", "domain/"} {
+		valid, violations := domainLayer.PackagesMustSharePrefix(prefix)
+		if valid || len(violations) != 2 ||
+			!strings.Contains(violations[0], `"domainx"`) || !strings.Contains(violations[1], `"shared/domain"`) {
+			t.Fatalf("Expected domainx and shared/domain to be reported in order for %q, got %v", prefix, violations)
+		}
+		for _, v := range violations {
+			t.Logf("  ✓ %s", v)
+		}
+	}
+}
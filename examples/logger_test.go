@@ -0,0 +1,40 @@
+package examples
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/mstrYoda/go-arctest/pkg/arctest"
+)
+
+// recordingLogger collects trace messages for inspection
+type recordingLogger struct {
+	mu       sync.Mutex
+	messages []string
+}
+
+func (l *recordingLogger) Printf(format string, args ...interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.messages = append(l.messages, fmt.Sprintf(format, args...))
+}
+
+// TestVerboseParsing demonstrates how to opt in to parse tracing
+func TestVerboseParsing(t *testing.T) {
+	logger := &recordingLogger{}
+	arch, err := arctest.New("./example_project", arctest.WithLogger(logger))
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	if err := arch.ParsePackages("domain"); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	traced := strings.Join(logger.messages, "\n")
+	if !strings.Contains(traced, "Found import in domain: github.com/mstrYoda/go-arctest/examples/example_project/utils") {
+		t.Errorf("Expected imports to be traced, got:\n%s", traced)
+	}
+}
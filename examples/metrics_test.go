@@ -0,0 +1,95 @@
+package examples
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/mstrYoda/go-arctest/pkg/arctest"
+)
+
+// TestPackageMetrics demonstrates how to compute instability and abstractness of packages
+func TestPackageMetrics(t *testing.T) {
+	arch, err := arctest.New("./example_project")
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	if err := arch.ParsePackages(); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	metrics := arch.Metrics()
+	if len(metrics) != len(arch.Packages) {
+		t.Fatalf("Expected metrics for all %d packages, got %d", len(arch.Packages), len(metrics))
+	}
+
+	// domain is imported by application and infrastructure and imports utils
+	domain := metrics["domain"]
+	if domain.Afferent != 2 || domain.Efferent != 1 || domain.Instability != 1.0/3 {
+		t.Errorf("Unexpected metrics for domain: %+v", domain)
+	}
+	if domain.Abstractness <= 0 || domain.Abstractness >= 1 {
+		t.Errorf("Expected domain to declare both interfaces and concrete types, got %+v", domain)
+	}
+	if utils := metrics["utils"]; utils.Instability != 0 || utils.Abstractness != 0 {
+		t.Errorf("Expected utils to be stable and concrete, got %+v", utils)
+	}
+	if presentation := metrics["presentation"]; presentation.Instability != 1 {
+		t.Errorf("Expected presentation to be maximally unstable, got %+v", presentation)
+	}
+
+	if valid, violations := arch.AssertStableDependencies(); !valid {
+		t.Errorf("Expected the example project to follow the stable dependencies principle, got %v", violations)
+	}
+}
+
+// TestStableDependenciesViolation demonstrates how a stable package depending on an unstable one is reported
+func TestStableDependenciesViolation(t *testing.T) {
+	fsys := fstest.MapFS{
+		"go.mod": {Data: []byte("module example.com/stability\n\ngo 1.20\n")},
+		"api/api.go": {Data: []byte(`package api
+
+import _ "example.com/stability/core"
+`)},
+		"cli/cli.go": {Data: []byte(`package cli
+
+import _ "example.com/stability/core"
+`)},
+		"core/core.go": {Data: []byte(`package core
+
+import _ "example.com/stability/helper"
+`)},
+		"helper/helper.go": {Data: []byte(`package helper
+
+import (
+	_ "example.com/stability/json"
+	_ "example.com/stability/yaml"
+)
+`)},
+		"json/json.go": {Data: []byte("package json\n")},
+		"yaml/yaml.go": {Data: []byte("package yaml\n")},
+	}
+
+	arch, err := arctest.NewFromFS(fsys, ".")
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	if err := arch.ParsePackages(); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	// core (I = 1/3) depends on helper (I = 2/3)
+	valid, violations := arch.AssertStableDependencies()
+	if valid || len(violations) != 1 || !strings.Contains(violations[0], `"core"`) || !strings.Contains(violations[0], `"helper"`) {
+		t.Fatalf("Expected only the core -> helper dependency to be reported, got %v", violations)
+	}
+	t.Logf("  ✓ %s", violations[0])
+
+	// Structured violations point at the offending import
+	_, detailed := arch.AssertStableDependenciesDetailed()
+	if len(detailed) != 1 || detailed[0].RuleType != arctest.RuleTypeCoupling || detailed[0].TargetPackage != "helper" || detailed[0].Line == 0 {
+		t.Errorf("Expected a coupling violation at the import of helper, got %+v", detailed)
+	}
+}
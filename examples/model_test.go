@@ -0,0 +1,69 @@
+package examples
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mstrYoda/go-arctest/pkg/arctest"
+)
+
+// TestDumpAndLoadModel demonstrates how to check rules against a previously dumped model
+func TestDumpAndLoadModel(t *testing.T) {
+	arch, err := arctest.New("./example_project")
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	if err := arch.ParsePackages(); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := arch.DumpModel(&buf); err != nil {
+		t.Fatalf("Failed to dump model: %v", err)
+	}
+
+	loaded, err := arctest.LoadModel(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("Failed to load model: %v", err)
+	}
+
+	if loaded.ModulePath != arch.ModulePath || len(loaded.Packages) != len(arch.Packages) {
+		t.Fatalf("Expected %d packages of %s, got %d of %s", len(arch.Packages), arch.ModulePath, len(loaded.Packages), loaded.ModulePath)
+	}
+	if loaded.GetPackage("infrastructure").Structs["UserRepository"].Pkg.Path != "infrastructure" {
+		t.Error("Expected the references between packages and their types to be restored")
+	}
+
+	// Rules give the same results for the loaded model as for the parsed packages
+	check := func(a *arctest.Architecture) []string {
+		domainLayer, err := arctest.NewLayer("Domain", "^domain$")
+		if err != nil {
+			t.Fatalf("Failed to create domain layer: %v", err)
+		}
+		utilsLayer, err := arctest.NewLayer("Utils", "^utils$")
+		if err != nil {
+			t.Fatalf("Failed to create utils layer: %v", err)
+		}
+		violations, err := a.NewLayeredArchitecture(domainLayer, utilsLayer).Check()
+		if err != nil {
+			t.Fatalf("Failed to check layered architecture: %v", err)
+		}
+		return violations
+	}
+	if want, got := check(arch), check(loaded); len(want) == 0 || !reflect.DeepEqual(want, got) {
+		t.Errorf("Expected violations %v for the loaded model, got %v", want, got)
+	}
+	if want, got := arch.Metrics(), loaded.Metrics(); !reflect.DeepEqual(want, got) {
+		t.Errorf("Expected metrics %v for the loaded model, got %v", want, got)
+	}
+
+	if err := loaded.ParsePackages(); err == nil {
+		t.Error("Expected parsing to fail for a loaded model without source tree")
+	}
+	if _, err := arctest.LoadModel(strings.NewReader(`{"Version": 99}`)); err == nil {
+		t.Error("Expected an error for an unsupported model version")
+	}
+}
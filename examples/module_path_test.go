@@ -0,0 +1,113 @@
+package examples
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/mstrYoda/go-arctest/pkg/arctest"
+)
+
+// TestModulePathResolution verifies that imports are mapped back to parsed packages via go.mod
+func TestModulePathResolution(t *testing.T) {
+	arch, err := arctest.New("./example_project")
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	if arch.ModulePath != "github.com/mstrYoda/go-arctest" {
+		t.Errorf("Unexpected module path: %q", arch.ModulePath)
+	}
+
+	if err := arch.ParsePackages("domain", "application"); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	importPath := arch.ImportPath("application/customer")
+	if importPath != "github.com/mstrYoda/go-arctest/examples/example_project/application/customer" {
+		t.Errorf("Unexpected import path: %q", importPath)
+	}
+
+	if pkgPath, ok := arch.ResolveImport(importPath); !ok || pkgPath != "application/customer" {
+		t.Errorf("Expected %q to resolve to application/customer, got %q", importPath, pkgPath)
+	}
+
+	if _, ok := arch.ResolveImport("github.com/other/project/domain"); ok {
+		t.Error("Expected an import from another module not to resolve")
+	}
+
+	domainLayer, err := arctest.NewLayer("Domain", "^domain$")
+	if err != nil {
+		t.Fatalf("Failed to create domain layer: %v", err)
+	}
+	arch.NewLayeredArchitecture(domainLayer)
+
+	if !domainLayer.Contains(arch.ImportPath("domain")) {
+		t.Error("Expected the domain layer to contain the full import path of the domain package")
+	}
+}
+
+// TestResolveImportWithoutModule verifies that imports resolve to the longest matching package path without a go.mod
+func TestResolveImportWithoutModule(t *testing.T) {
+	arch, err := arctest.NewFromFS(fstest.MapFS{
+		"user/user.go":        {Data: []byte("package user\n")},
+		"domain/user/user.go": {Data: []byte("package user\n")},
+		"legacy/user/user.go": {Data: []byte("package user\n")},
+		"app/app.go": {Data: []byte(`package app
+
+import _ "github.com/acme/shop/domain/user"
+`)},
+	}, ".", arctest.WithExclude("^legacy/user$"))
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	if err := arch.ParsePackages(); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	// Map iteration order must not decide between packages sharing a suffix
+	for run := 0; run < 50; run++ {
+		if pkgPath, ok := arch.ResolveImport("github.com/acme/shop/domain/user"); !ok || pkgPath != "domain/user" {
+			t.Fatalf("Expected the import to resolve to domain/user, got %q", pkgPath)
+		}
+		if pkgPath, ok := arch.ResolveImport("github.com/acme/shop/legacy/user"); !ok || pkgPath != "legacy/user" {
+			t.Fatalf("Expected the import to resolve to the excluded legacy/user, got %q", pkgPath)
+		}
+		if pkgPath, ok := arch.ResolveImport("github.com/acme/shop/user"); !ok || pkgPath != "user" {
+			t.Fatalf("Expected the import to resolve to user, got %q", pkgPath)
+		}
+	}
+
+	if dependencies := arch.Dependencies("app"); len(dependencies) != 1 || dependencies[0] != "domain/user" {
+		t.Errorf("Expected app to depend on domain/user only, got %v", dependencies)
+	}
+}
+
+// TestResolveRootPackageImport verifies that an import of the module path itself resolves to the root package
+func TestResolveRootPackageImport(t *testing.T) {
+	arch, err := arctest.NewFromFS(fstest.MapFS{
+		"go.mod":  {Data: []byte("module example.com/shop\n\ngo 1.20\n")},
+		"shop.go": {Data: []byte("package shop\n")},
+		"cmd/server/main.go": {Data: []byte(`package main
+
+import _ "example.com/shop"
+`)},
+	}, ".")
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	if err := arch.ParsePackages(); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	if pkgPath, ok := arch.ResolveImport("example.com/shop"); !ok || pkgPath != "." {
+		t.Errorf("Expected the module path to resolve to the root package, got %q", pkgPath)
+	}
+	if _, ok := arch.ResolveImport("example.com/shopping"); ok {
+		t.Error("Expected a module path sharing the prefix not to resolve")
+	}
+	if dependencies := arch.Dependencies("cmd/server"); len(dependencies) != 1 || dependencies[0] != "." {
+		t.Errorf("Expected cmd/server to depend on the root package, got %v", dependencies)
+	}
+}
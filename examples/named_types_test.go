@@ -0,0 +1,90 @@
+package examples
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/mstrYoda/go-arctest/pkg/arctest"
+)
+
+// namedTypesProject declares named types and aliases besides structs and interfaces
+var namedTypesProject = fstest.MapFS{
+	"go.mod": {Data: []byte("module example.com/accounts\n\ngo 1.20\n")},
+	"domain/account.go": {Data: []byte(`package domain
+
+import "net/http"
+
+// AccountID identifies an account
+type AccountID string
+
+// AccountRepository stores accounts
+type AccountRepository interface {
+	Find(id AccountID) error
+}
+
+// Repository is a shorter name for AccountRepository
+type Repository = AccountRepository
+
+// Handler serves account requests
+type Handler = http.HandlerFunc
+`)},
+	"service/service.go": {Data: []byte(`package service
+
+import "example.com/accounts/domain"
+
+// AccountService manages accounts
+type AccountService struct{}
+
+// Use sets the repository of the service
+func (s *AccountService) Use(repo domain.Repository) {}
+`)},
+}
+
+// TestNamedTypesAndAliases verifies that named types and aliases are recorded and that
+// rules resolve aliases to the type they refer to
+func TestNamedTypesAndAliases(t *testing.T) {
+	arch, err := arctest.NewFromFS(namedTypesProject, ".")
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	if err := arch.ParsePackages(); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	domain := arch.GetPackage("domain")
+	if id, ok := domain.NamedTypes["AccountID"]; !ok || id.Underlying != "string" || id.Alias {
+		t.Errorf("Expected AccountID to be recorded as a named type, got %+v", id)
+	}
+	if handler, ok := domain.TypeAliases["Handler"]; !ok || handler.Underlying != "http.HandlerFunc" || !handler.Alias {
+		t.Errorf("Expected Handler to be recorded as an alias, got %+v", handler)
+	}
+
+	// The Repository alias refers to an interface, so it is not a struct parameter
+	rule, err := arch.MethodsShouldUseStructParameters(".*Service$", ".*", ".*Repository$")
+	if err != nil {
+		t.Fatalf("Failed to create parameter rule: %v", err)
+	}
+
+	valid, violations := arch.ValidateMethodParameters([]*arctest.ParameterRule{rule})
+	if valid || len(violations) != 1 || !strings.Contains(violations[0], `uses interface type "domain.Repository"`) {
+		t.Errorf("Expected the alias to be resolved to an interface, got %v", violations)
+	}
+
+	// Naming rules see named types and aliases as well
+	domainLayer, err := arctest.NewLayer("Domain", "^domain$")
+	if err != nil {
+		t.Fatalf("Failed to create domain layer: %v", err)
+	}
+
+	namingRule, err := domainLayer.EnforceNamedTypeNaming("(ID|Repository)$")
+	if err != nil {
+		t.Fatalf("Failed to create naming rule: %v", err)
+	}
+
+	valid, violations = arch.ValidateNamingRules([]*arctest.NamingRule{namingRule})
+	if valid || len(violations) != 1 || !strings.Contains(violations[0], `Type "Handler"`) {
+		t.Errorf("Expected only Handler to violate the naming rule, got %v", violations)
+	}
+}
@@ -0,0 +1,162 @@
+package examples
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/mstrYoda/go-arctest/pkg/arctest"
+)
+
+// TestLayerNamingRules demonstrates how to enforce naming conventions per layer
+func TestLayerNamingRules(t *testing.T) {
+	arch, err := arctest.New("./example_project")
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	if err := arch.ParsePackages("domain", "infrastructure", "presentation"); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	infrastructureLayer, err := arctest.NewLayer("Infrastructure", "^infrastructure$")
+	if err != nil {
+		t.Fatalf("Failed to create infrastructure layer: %v", err)
+	}
+
+	presentationLayer, err := arctest.NewLayer("Presentation", "^presentation$")
+	if err != nil {
+		t.Fatalf("Failed to create presentation layer: %v", err)
+	}
+
+	repositoryNaming, err := infrastructureLayer.EnforceStructNaming("(Repository|Adapter)$")
+	if err != nil {
+		t.Fatalf("Failed to create naming rule: %v", err)
+	}
+
+	handlerNaming, err := presentationLayer.EnforceStructNaming("Handler$")
+	if err != nil {
+		t.Fatalf("Failed to create naming rule: %v", err)
+	}
+
+	valid, violations := arch.ValidateNamingRules([]*arctest.NamingRule{repositoryNaming, handlerNaming})
+	if valid {
+		t.Fatal("Expected naming violations, but none were found!")
+	}
+
+	reported := strings.Join(violations, "\n")
+	if !strings.Contains(reported, `Struct "UserCache" in package "infrastructure" of layer "Infrastructure"`) {
+		t.Errorf("Expected UserCache to be reported, got %v", violations)
+	}
+	if strings.Contains(reported, `"UserRepository"`) || strings.Contains(reported, `"UserHandler"`) {
+		t.Errorf("Expected conforming structs not to be reported, got %v", violations)
+	}
+	for _, violation := range violations {
+		t.Logf("  ✓ %s", violation)
+	}
+}
+
+// TestInterfacesMustNotBePrefixedWithI demonstrates how to find C#/Java-style interface names
+func TestInterfacesMustNotBePrefixedWithI(t *testing.T) {
+	arch, err := arctest.NewFromFS(fstest.MapFS{
+		"go.mod": {Data: []byte("module example.com/shop\n\ngo 1.20\n")},
+		"domain/user.go": {Data: []byte(`package domain
+
+// IUserRepository stores users
+type IUserRepository interface {
+	Save() error
+}
+
+// Identifier identifies entities and is not prefixed
+type Identifier interface {
+	ID() string
+}
+`)},
+		"application/service.go": {Data: []byte(`package application
+
+// IService runs use cases
+type IService interface {
+	Run() error
+}
+
+// Importer imports users and is not prefixed either
+type Importer interface {
+	Import() error
+}
+`)},
+	}, ".")
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	if err := arch.ParsePackages(); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	valid, violations := arch.InterfacesMustNotBePrefixedWithI()
+	if valid || len(violations) != 2 {
+		t.Fatalf("Expected IService and IUserRepository to be reported, got %v", violations)
+	}
+	if !strings.Contains(violations[0], `"IService" in package "application"`) ||
+		!strings.Contains(violations[1], `"IUserRepository" in package "domain"`) {
+		t.Errorf("Expected violations sorted by package path, got %v", violations)
+	}
+	for _, v := range violations {
+		t.Logf("  ✓ %s", v)
+	}
+}
+
+// TestNamingRuleOrder verifies that naming violations are sorted by package and type name
+func TestNamingRuleOrder(t *testing.T) {
+	arch, err := arctest.NewFromFS(fstest.MapFS{
+		"go.mod": {Data: []byte("module example.com/shop\n\ngo 1.20\n")},
+		"infrastructure/postgres/store.go": {Data: []byte(`package postgres
+
+type Store struct{}
+
+type Cache struct{}
+
+type Pool struct{}
+`)},
+		"infrastructure/memory/store.go": {Data: []byte(`package memory
+
+type Store struct{}
+
+type Index struct{}
+`)},
+	}, ".")
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	if err := arch.ParsePackages(); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	infrastructureLayer, err := arctest.NewLayer("Infrastructure", "^infrastructure/.*$")
+	if err != nil {
+		t.Fatalf("Failed to create infrastructure layer: %v", err)
+	}
+	rule, err := infrastructureLayer.EnforceStructNaming("Repository$")
+	if err != nil {
+		t.Fatalf("Failed to create naming rule: %v", err)
+	}
+
+	expected := []string{
+		`"Index" in package "infrastructure/memory"`,
+		`"Store" in package "infrastructure/memory"`,
+		`"Cache" in package "infrastructure/postgres"`,
+		`"Pool" in package "infrastructure/postgres"`,
+		`"Store" in package "infrastructure/postgres"`,
+	}
+	_, violations := arch.ValidateNamingRules([]*arctest.NamingRule{rule})
+	if len(violations) != len(expected) {
+		t.Fatalf("Expected %d naming violations, got %v", len(expected), violations)
+	}
+	for idx, v := range violations {
+		if !strings.Contains(v, expected[idx]) {
+			t.Errorf("Expected violation %d to report %s, got %s", idx, expected[idx], v)
+		}
+		t.Logf("  ✓ %s", v)
+	}
+}
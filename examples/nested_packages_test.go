@@ -2,6 +2,7 @@ package examples
 
 import (
 	"testing"
+	"testing/fstest"
 
 	"github.com/mstrYoda/go-arctest/pkg/arctest"
 )
@@ -191,3 +192,49 @@ func TestNestedPackagesDependencyViolation(t *testing.T) {
 		t.Logf("No layered architecture violations found")
 	}
 }
+
+// TestAnchoredPatternsWithSubpackages verifies which packages anchored and simple layer
+// patterns cover
+func TestAnchoredPatternsWithSubpackages(t *testing.T) {
+	fsys := fstest.MapFS{
+		"domain/user/user.go":          {Data: []byte("package user\n")},
+		"internal/domain/domain.go":    {Data: []byte("package domain\n")},
+		"internal/domain/user/user.go": {Data: []byte("package user\n")},
+	}
+
+	arch, err := arctest.NewFromFS(fsys, ".")
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	if err := arch.ParsePackages(); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	// Simple patterns cover their subpackages by default
+	simpleLayer, err := arctest.NewLayer("Domain", "^domain$")
+	if err != nil {
+		t.Fatalf("Failed to create domain layer: %v", err)
+	}
+	if !simpleLayer.Contains("domain/user") {
+		t.Errorf("Expected ^domain$ to cover domain/user")
+	}
+
+	// Path patterns only match the package itself
+	anchoredLayer, err := arctest.NewLayer("Domain", "^internal/domain$")
+	if err != nil {
+		t.Fatalf("Failed to create domain layer: %v", err)
+	}
+	if !anchoredLayer.Contains("internal/domain") || anchoredLayer.Contains("internal/domain/user") {
+		t.Errorf("Expected ^internal/domain$ to only cover internal/domain")
+	}
+
+	// unless subpackages are included explicitly
+	anchoredLayer.IncludingSubpackages()
+	if !anchoredLayer.Contains("internal/domain/user") {
+		t.Errorf("Expected ^internal/domain$ to cover internal/domain/user when including subpackages")
+	}
+	if anchoredLayer.Contains("internal/domainx") || anchoredLayer.Contains("internal") {
+		t.Errorf("Expected only descendants of internal/domain to be covered")
+	}
+}
@@ -0,0 +1,46 @@
+package examples
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/mstrYoda/go-arctest/pkg/arctest"
+)
+
+// TestMaxPackageDepth demonstrates how to limit the nesting of packages
+func TestMaxPackageDepth(t *testing.T) {
+	arch, err := arctest.NewFromFS(fstest.MapFS{
+		"go.mod":                                    {Data: []byte("module example.com/shop\n\ngo 1.20\n")},
+		"domain/order.go":                           {Data: []byte("package domain\n")},
+		"domain/order/events/v1/created.go":         {Data: []byte("package v1\n")},
+		"cmd/server/main.go":                        {Data: []byte("package main\n")},
+		"internal/platform/db/postgres/postgres.go": {Data: []byte("package postgres\n")},
+		"internal/platform/db/db.go":                {Data: []byte("package db\n")},
+	}, ".")
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	if err := arch.ParsePackages(); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	violations := arch.MaxPackageDepth(3)
+	if len(violations) != 2 {
+		t.Fatalf("Expected both packages nested 4 levels deep to be reported, got %v", violations)
+	}
+
+	// Below internal/, postgres is only nested 3 levels deep
+	violations = arch.MaxPackageDepth(3, "cmd", "internal")
+	if len(violations) != 1 || !strings.Contains(violations[0], `"domain/order/events/v1"`) {
+		t.Fatalf("Expected only the domain events to be reported, got %v", violations)
+	}
+	t.Logf("  ✓ %s", violations[0])
+
+	violations = arch.MaxPackageDepth(2, "cmd", "internal")
+	if len(violations) != 2 || !strings.Contains(violations[1], `nested 3 levels below "internal"`) {
+		t.Fatalf("Expected the domain events and postgres to be reported, got %v", violations)
+	}
+	t.Logf("  ✓ %s", violations[1])
+}
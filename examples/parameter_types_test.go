@@ -0,0 +1,146 @@
+package examples
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/mstrYoda/go-arctest/pkg/arctest"
+)
+
+// parameterTypesProject has structs whose methods only match the interface by parameter and result count
+var parameterTypesProject = fstest.MapFS{
+	"go.mod": {Data: []byte("module example.com/users\n\ngo 1.20\n")},
+	"domain/user.go": {Data: []byte(`package domain
+
+// User is a user of the system
+type User struct {
+	ID string
+}
+
+// UserSaver saves users
+type UserSaver interface {
+	Save(user *User) error
+}
+`)},
+	"store/store.go": {Data: []byte(`package store
+
+import "example.com/users/domain"
+
+// UserStore saves users
+type UserStore struct{}
+
+// Save saves a user
+func (s *UserStore) Save(user *domain.User) error {
+	return nil
+}
+
+// IDStore saves identifiers
+type IDStore struct{}
+
+// Save saves an identifier
+func (s *IDStore) Save(id string) error {
+	return nil
+}
+
+// CountingStore saves users and reports how many it saved
+type CountingStore struct{}
+
+// Save saves a user
+func (s *CountingStore) Save(user *domain.User) int {
+	return 1
+}
+`)},
+}
+
+// TestParameterTypesCompared verifies that methods only match if their parameter and result
+// types match, unless loose matching is enabled
+func TestParameterTypesCompared(t *testing.T) {
+	for _, loose := range []bool{false, true} {
+		arch, err := arctest.NewFromFS(parameterTypesProject, ".", arctest.WithLooseMatching(loose))
+		if err != nil {
+			t.Fatalf("Failed to create architecture: %v", err)
+		}
+
+		if err := arch.ParsePackages(); err != nil {
+			t.Fatalf("Failed to parse packages: %v", err)
+		}
+
+		saver := arch.GetPackage("domain").Interfaces["UserSaver"]
+		store := arch.GetPackage("store")
+
+		if ok, missing := arctest.CheckInterfaceImplementation(store.Structs["UserStore"], saver); !ok {
+			t.Errorf("Expected UserStore to implement UserSaver, missing %v", missing)
+		}
+
+		ok, _ := arctest.CheckInterfaceImplementation(store.Structs["IDStore"], saver)
+		if ok != loose {
+			t.Errorf("Expected IDStore implementing UserSaver to be %t with loose matching %t", loose, loose)
+		}
+
+		// Result types are relaxed the same way as parameter types
+		ok, _ = arctest.CheckInterfaceImplementation(store.Structs["CountingStore"], saver)
+		if ok != loose {
+			t.Errorf("Expected CountingStore implementing UserSaver to be %t with loose matching %t", loose, loose)
+		}
+	}
+}
+
+// TestAnonymousInterfaceParameters verifies that inline interface types and any count as interfaces
+func TestAnonymousInterfaceParameters(t *testing.T) {
+	arch, err := arctest.NewFromFS(fstest.MapFS{
+		"go.mod": {Data: []byte("module example.com/users\n\ngo 1.20\n")},
+		"domain/handler.go": {Data: []byte(`package domain
+
+// Handler handles events
+type Handler interface {
+	Handle(logger interface{ Log(message string) }, event any) error
+}
+`)},
+		"application/handler.go": {Data: []byte(`package application
+
+// Config is a concrete configuration
+type Config struct{}
+
+// EventHandler handles events
+type EventHandler struct{}
+
+// Handle handles an event
+func (h *EventHandler) Handle(logger interface{ Log(string) }, event any) error {
+	return nil
+}
+
+// Configure configures the handler
+func (h *EventHandler) Configure(config *Config, options interface{}) {}
+`)},
+	}, ".")
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	if err := arch.ParsePackages(); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	handler := arch.GetPackage("application").Structs["EventHandler"]
+	if got := handler.Methods[0].Params[0].Type; got != "interface{ Log(string) }" {
+		t.Errorf("Expected the inline interface to be rendered with its methods, got %q", got)
+	}
+
+	// Method names inside inline interfaces aren't qualified with the declaring package
+	iface := arch.GetPackage("domain").Interfaces["Handler"]
+	if ok, missing := arctest.CheckInterfaceImplementation(handler, iface); !ok {
+		t.Errorf("Expected EventHandler to implement Handler, missing %v", missing)
+	}
+
+	rule, err := arch.MethodsShouldUseInterfaceParameters("Handler$", ".*", ".*")
+	if err != nil {
+		t.Fatalf("Failed to create parameter rule: %v", err)
+	}
+
+	valid, violations := arch.ValidateMethodParameters([]*arctest.ParameterRule{rule})
+	if valid || len(violations) != 1 || !strings.Contains(violations[0], `"Config"`) {
+		t.Fatalf("Expected only the struct parameter to be reported, got %v", violations)
+	}
+	t.Logf("  ✓ %s", violations[0])
+}
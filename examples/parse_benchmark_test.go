@@ -0,0 +1,32 @@
+package examples
+
+import (
+	"testing"
+
+	"github.com/mstrYoda/go-arctest/pkg/arctest"
+)
+
+// BenchmarkParsePackages compares sequential parsing with parsing on all available CPUs
+func BenchmarkParsePackages(b *testing.B) {
+	benchmarks := []struct {
+		name    string
+		workers int
+	}{
+		{"Sequential", 1},
+		{"Parallel", 0},
+	}
+
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				arch, err := arctest.New("..", arctest.WithParallelism(bm.workers))
+				if err != nil {
+					b.Fatalf("Failed to create architecture: %v", err)
+				}
+				if err := arch.ParsePackages(); err != nil {
+					b.Fatalf("Failed to parse packages: %v", err)
+				}
+			}
+		})
+	}
+}
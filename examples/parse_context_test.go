@@ -0,0 +1,42 @@
+package examples
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/mstrYoda/go-arctest/pkg/arctest"
+)
+
+// TestParsePackagesContext demonstrates how to cancel parsing through a context
+func TestParsePackagesContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	for _, paths := range [][]string{nil, {"domain", "application"}} {
+		arch, err := arctest.New("./example_project")
+		if err != nil {
+			t.Fatalf("Failed to create architecture: %v", err)
+		}
+
+		err = arch.ParsePackagesContext(ctx, paths...)
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected parsing %v to be canceled, got %v", paths, err)
+		}
+		if len(arch.Packages) != 0 {
+			t.Errorf("Expected no packages to be parsed after cancellation, got %d", len(arch.Packages))
+		}
+	}
+
+	arch, err := arctest.New("./example_project")
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	if err := arch.ParsePackagesContext(context.Background(), "domain"); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+	if arch.GetPackage("domain") == nil {
+		t.Errorf("Expected the domain package to be parsed")
+	}
+}
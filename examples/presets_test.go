@@ -0,0 +1,199 @@
+package examples
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/mstrYoda/go-arctest/pkg/arctest"
+)
+
+// hexagonalProject is a ports and adapters project whose domain wrongly imports an adapter
+var hexagonalProject = fstest.MapFS{
+	"go.mod": {Data: []byte("module example.com/shop\n\ngo 1.20\n")},
+	"domain/order.go": {Data: []byte(`package domain
+
+import "example.com/shop/adapters/postgres"
+
+// Order is a customer order
+type Order struct {
+	Store *postgres.OrderStore
+}
+`)},
+	"ports/orders.go": {Data: []byte(`package ports
+
+import "example.com/shop/domain"
+
+// OrderRepository stores orders
+type OrderRepository interface {
+	Save(order *domain.Order) error
+}
+`)},
+	"adapters/postgres/orders.go": {Data: []byte(`package postgres
+
+import (
+	"example.com/shop/domain"
+	"example.com/shop/ports"
+)
+
+// OrderStore stores orders in PostgreSQL
+type OrderStore struct{}
+
+var _ ports.OrderRepository = (*OrderStore)(nil)
+
+// Save stores an order
+func (s *OrderStore) Save(order *domain.Order) error {
+	return nil
+}
+`)},
+}
+
+// TestHexagonalArchitecture demonstrates the ports and adapters preset
+func TestHexagonalArchitecture(t *testing.T) {
+	arch, err := arctest.NewFromFS(hexagonalProject, ".")
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	if err := arch.ParsePackages(); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	layeredArch, err := arctest.NewHexagonalArchitecture(arch, "^domain$", "^ports$", "^adapters$")
+	if err != nil {
+		t.Fatalf("Failed to create hexagonal architecture: %v", err)
+	}
+
+	violations, err := layeredArch.Check()
+	if err != nil {
+		t.Fatalf("Failed to check layered architecture: %v", err)
+	}
+
+	// Adapters may use ports and the domain, but the domain must not use adapters
+	if len(violations) != 1 || !strings.Contains(violations[0], `Package "domain" in layer "Domain" imports "example.com/shop/adapters/postgres" in layer "Adapters"`) {
+		t.Fatalf("Expected only the domain -> adapters dependency to be reported, got %v", violations)
+	}
+	t.Logf("  ✓ %s", violations[0])
+}
+
+// TestHexagonalArchitectureAllowByDefault verifies that the preset forbids outward dependencies without a default deny
+func TestHexagonalArchitectureAllowByDefault(t *testing.T) {
+	project := fstest.MapFS{
+		"ports/cache.go": {Data: []byte(`package ports
+
+import _ "example.com/shop/adapters/postgres"
+`)},
+	}
+	for name, file := range hexagonalProject {
+		project[name] = file
+	}
+
+	arch, err := arctest.NewFromFS(project, ".")
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	if err := arch.ParsePackages(); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	layeredArch, err := arctest.NewHexagonalArchitecture(arch, "^domain$", "^ports$", "^adapters$")
+	if err != nil {
+		t.Fatalf("Failed to create hexagonal architecture: %v", err)
+	}
+	layeredArch.SetPolicyMode(arctest.AllowByDefault)
+
+	violations, err := layeredArch.Check()
+	if err != nil {
+		t.Fatalf("Failed to check layered architecture: %v", err)
+	}
+
+	if len(violations) != 2 ||
+		!strings.Contains(strings.Join(violations, "\n"), `Package "domain" in layer "Domain" imports "example.com/shop/adapters/postgres"`) ||
+		!strings.Contains(strings.Join(violations, "\n"), `Package "ports" in layer "Ports" imports "example.com/shop/adapters/postgres"`) {
+		t.Fatalf("Expected the domain -> adapters and ports -> adapters dependencies to be reported, got %v", violations)
+	}
+	for _, v := range violations {
+		t.Logf("  ✓ %s", v)
+	}
+}
+
+// cleanProject is a Clean Architecture project whose use cases wrongly import a framework
+var cleanProject = fstest.MapFS{
+	"go.mod": {Data: []byte("module example.com/blog\n\ngo 1.20\n")},
+	"entities/post.go": {Data: []byte(`package entities
+
+// Post is a blog post
+type Post struct {
+	Title string
+}
+`)},
+	"usecases/publish.go": {Data: []byte(`package usecases
+
+import (
+	"example.com/blog/entities"
+	"example.com/blog/frameworks/web"
+)
+
+// Publish publishes a post
+func Publish(post *entities.Post) error {
+	return web.Notify(post.Title)
+}
+`)},
+	"adapters/posts.go": {Data: []byte(`package adapters
+
+import (
+	"example.com/blog/entities"
+	"example.com/blog/usecases"
+)
+
+// PublishHandler adapts requests to the publish use case
+func PublishHandler(title string) error {
+	return usecases.Publish(&entities.Post{Title: title})
+}
+`)},
+	"frameworks/web/server.go": {Data: []byte(`package web
+
+// Notify notifies subscribers
+func Notify(title string) error {
+	return nil
+}
+`)},
+}
+
+// TestCleanArchitecture demonstrates the Clean Architecture preset
+func TestCleanArchitecture(t *testing.T) {
+	arch, err := arctest.NewFromFS(cleanProject, ".")
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	if err := arch.ParsePackages(); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	layeredArch, err := arctest.NewCleanArchitecture(arch, "^entities$", "^usecases$", "^adapters$", "^frameworks$")
+	if err != nil {
+		t.Fatalf("Failed to create clean architecture: %v", err)
+	}
+
+	// The outward dependency is reported in both policy modes
+	for _, mode := range []arctest.PolicyMode{arctest.DenyByDefault, arctest.AllowByDefault} {
+		layeredArch.SetPolicyMode(mode)
+
+		violations, err := layeredArch.Check()
+		if err != nil {
+			t.Fatalf("Failed to check layered architecture: %v", err)
+		}
+
+		if len(violations) != 1 || !strings.Contains(violations[0], `Package "usecases" in layer "UseCases" imports "example.com/blog/frameworks/web" in layer "Frameworks"`) {
+			t.Fatalf("Expected only the usecases -> frameworks dependency to be reported, got %v", violations)
+		}
+		t.Logf("  ✓ %s", violations[0])
+	}
+
+	// The preset is built from layer rules, which are named after the layers
+	if unused := layeredArch.UnusedRules(); !strings.Contains(strings.Join(unused, "\n"), `layer "Frameworks" may depend on layer "Entities"`) {
+		t.Errorf("Expected the unused rules to be named after the layers, got %v", unused)
+	}
+}
@@ -0,0 +1,48 @@
+package examples
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mstrYoda/go-arctest/pkg/arctest"
+)
+
+// TestReceiverStyle demonstrates how to enforce pointer receivers on repositories
+func TestReceiverStyle(t *testing.T) {
+	arch, err := arctest.New("./example_project")
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	if err := arch.ParsePackages(); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	// UserRepository uses pointer receivers throughout
+	violations, err := arch.CheckReceiverStyle("^UserRepository$", true)
+	if err != nil {
+		t.Fatalf("Failed to check receivers: %v", err)
+	}
+	if len(violations) != 0 {
+		t.Errorf("Expected UserRepository to use pointer receivers only, got %v", violations)
+	}
+
+	// CachedUserRepository declares Save and Delete on the value type
+	violations, err = arch.CheckReceiverStyle("Repository$", true)
+	if err != nil {
+		t.Fatalf("Failed to check receivers: %v", err)
+	}
+	if len(violations) != 2 {
+		t.Fatalf("Expected the two value receivers of CachedUserRepository to be reported, got %v", violations)
+	}
+	for _, v := range violations {
+		if !strings.Contains(v, `struct "CachedUserRepository"`) {
+			t.Errorf("Unexpected violation: %s", v)
+		}
+		t.Logf("  ✓ %s", v)
+	}
+
+	if _, err := arch.CheckReceiverStyle("(", true); err == nil {
+		t.Error("Expected an error for an invalid struct pattern")
+	}
+}
@@ -0,0 +1,57 @@
+package examples
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/mstrYoda/go-arctest/pkg/arctest"
+)
+
+// TestReset demonstrates how to reparse a changed source tree with the same architecture
+func TestReset(t *testing.T) {
+	fsys := fstest.MapFS{
+		"go.mod":         {Data: []byte("module example.com/shop\n\ngo 1.20\n")},
+		"domain/user.go": {Data: []byte("package domain\n\n// User is a customer\ntype User struct{}\n")},
+		"legacy/db.go":   {Data: []byte("package legacy\n")},
+	}
+
+	arch, err := arctest.NewFromFS(fsys, ".", arctest.WithExclude("^legacy$"))
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+	if err := arch.ParsePackages(); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	// Rename the module, move the user into another package and add an order
+	fsys["go.mod"] = &fstest.MapFile{Data: []byte("module example.com/store\n\ngo 1.20\n")}
+	delete(fsys, "domain/user.go")
+	fsys["customer/user.go"] = &fstest.MapFile{Data: []byte("package customer\n\n// User is a customer\ntype User struct{}\n")}
+	fsys["domain/order.go"] = &fstest.MapFile{Data: []byte("package domain\n\n// Order is a customer order\ntype Order struct{}\n")}
+
+	if err := arch.Reset(); err != nil {
+		t.Fatalf("Failed to reset architecture: %v", err)
+	}
+	if len(arch.Packages) != 0 {
+		t.Fatalf("Expected no packages after a reset, got %d", len(arch.Packages))
+	}
+	if err := arch.ParsePackages(); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	if arch.ModulePath != "example.com/store" {
+		t.Errorf("Expected the renamed module, got %q", arch.ModulePath)
+	}
+	if domain := arch.GetPackage("domain"); domain == nil || domain.Structs["User"] != nil || domain.Structs["Order"] == nil {
+		t.Errorf("Expected the domain package to only contain the order, got %+v", domain)
+	}
+	if arch.GetPackage("customer") == nil {
+		t.Errorf("Expected the new customer package to be parsed")
+	}
+	if arch.GetPackage("legacy") != nil {
+		t.Errorf("Expected the exclude option to be kept")
+	}
+	if resolved, ok := arch.ResolveImport("example.com/store/customer"); !ok || resolved != "customer" {
+		t.Errorf("Expected imports of the renamed module to resolve, got %q", resolved)
+	}
+}
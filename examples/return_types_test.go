@@ -0,0 +1,115 @@
+package examples
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mstrYoda/go-arctest/pkg/arctest"
+)
+
+// TestMethodsDifferingOnlyByReturnType verifies that a struct whose method differs from
+// the interface only by its return types is not treated as an implementation
+func TestMethodsDifferingOnlyByReturnType(t *testing.T) {
+	arch, err := arctest.New("./example_project")
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	if err := arch.ParsePackages("domain", "infrastructure"); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	cache := arch.GetPackage("infrastructure").Structs["UserCache"]
+	var findByID *arctest.Method
+	for _, m := range cache.Methods {
+		if m.Name == "FindByID" {
+			findByID = m
+		}
+	}
+	if findByID == nil || len(findByID.Returns) != 1 || findByID.Returns[0].Type != "error" {
+		t.Fatalf("Expected UserCache.FindByID to return a single error, got %+v", findByID)
+	}
+
+	implementations, err := arch.FindAllImplementations("UserRepositoryInterface", "domain")
+	if err != nil {
+		t.Fatalf("Failed to find implementations: %v", err)
+	}
+
+	found := map[string]bool{}
+	for _, s := range implementations {
+		found[s.Name] = true
+	}
+	if !found["UserRepository"] {
+		t.Error("Expected UserRepository to implement UserRepositoryInterface")
+	}
+	if found["UserCache"] {
+		t.Error("Expected UserCache not to implement UserRepositoryInterface, as FindByID returns only an error")
+	}
+
+	ranked := arch.RankImplementations("UserRepositoryInterface", "domain")
+	for _, match := range ranked {
+		if match.Struct.Name != "UserCache" {
+			continue
+		}
+		if match.MatchedMethods != 3 || len(match.MissingMethods) != 1 || match.MissingMethods[0] != "FindByID" {
+			t.Errorf("Expected UserCache to match 3 methods and miss FindByID, got %d matched and missing %v",
+				match.MatchedMethods, match.MissingMethods)
+		}
+	}
+}
+
+// TestPointerReceiverImplementations verifies that implementations relying on pointer
+// receivers are reported as only implemented by the pointer type
+func TestPointerReceiverImplementations(t *testing.T) {
+	arch, err := arctest.New("./example_project")
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	if err := arch.ParsePackages("domain", "infrastructure"); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	implementations, err := arch.FindAllImplementationsWithReceivers("UserRepositoryInterface", "domain")
+	if err != nil {
+		t.Fatalf("Failed to find implementations: %v", err)
+	}
+
+	for _, impl := range implementations {
+		if impl.Struct.Name != "UserRepository" {
+			continue
+		}
+		if impl.Kind != arctest.PointerImplements {
+			t.Errorf("Expected only *UserRepository to implement UserRepositoryInterface, got %s", impl.Kind)
+		}
+		return
+	}
+	t.Error("Expected UserRepository to implement UserRepositoryInterface")
+}
+
+// TestMissingInterfaceMethodReported verifies that violations name the unsatisfied methods
+// of the closest matching interface
+func TestMissingInterfaceMethodReported(t *testing.T) {
+	arch, err := arctest.New("./example_project")
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	if err := arch.ParsePackages("domain", "infrastructure"); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	rule, err := arch.StructsImplementInterfaces(".*Cache$", ".*RepositoryInterface$")
+	if err != nil {
+		t.Fatalf("Failed to create interface implementation rule: %v", err)
+	}
+
+	valid, violations := arch.ValidateInterfaceImplementations([]*arctest.InterfaceImplementationRule{rule})
+	if valid || len(violations) != 1 {
+		t.Fatalf("Expected exactly one violation, got %v", violations)
+	}
+	if !strings.Contains(violations[0], `closest is "UserRepositoryInterface"`) ||
+		!strings.Contains(violations[0], "FindByID (signature mismatch)") {
+		t.Errorf("Expected the violation to name the mismatched method, got %s", violations[0])
+	}
+}
@@ -0,0 +1,126 @@
+package examples
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/mstrYoda/go-arctest/pkg/arctest"
+)
+
+// TestStructFieldsShouldUseInterfaces demonstrates how to enforce that service
+// dependencies are held as interfaces rather than concrete structs
+func TestStructFieldsShouldUseInterfaces(t *testing.T) {
+	arch, err := arctest.New("./example_project")
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	if err := arch.ParsePackages("domain", "application", "utils"); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	rule, err := arch.StructFieldsShouldUseInterfaces(".*Service.*", ".*", ".*")
+	if err != nil {
+		t.Fatalf("Failed to create field rule: %v", err)
+	}
+
+	violations := arch.CheckStructFields([]*arctest.FieldRule{rule})
+
+	// UserServiceWithLogger holds a concrete *utils.Logger, while UserService holds an interface
+	if len(violations) != 1 {
+		t.Fatalf("Expected exactly one field violation, got %v", violations)
+	}
+	if !strings.Contains(violations[0], `Field "logger" of struct "UserServiceWithLogger"`) {
+		t.Errorf("Unexpected violation: %s", violations[0])
+	}
+	t.Logf("  ✓ %s", violations[0])
+}
+
+// TestFieldAndParameterRulesAgree verifies that field rules classify types like parameter
+// rules, resolving import aliases and type aliases and honoring the rule's scope
+func TestFieldAndParameterRulesAgree(t *testing.T) {
+	arch, err := arctest.NewFromFS(fstest.MapFS{
+		"go.mod": {Data: []byte("module example.com/shop\n\ngo 1.20\n")},
+		"domain/repository.go": {Data: []byte(`package domain
+
+type Store interface{ Save() }
+
+type Repository = Store
+`)},
+		"postgres/store.go": {Data: []byte(`package postgres
+
+type Store struct{}
+`)},
+		"application/service.go": {Data: []byte(`package application
+
+import (
+	d "example.com/shop/domain"
+	pg "example.com/shop/postgres"
+)
+
+type OrderService struct {
+	store   d.Store
+	repo    d.Repository
+	cache   any
+	backend *pg.Store
+}
+
+func (s *OrderService) Use(store d.Store, repo d.Repository, cache any, backend *pg.Store) {}
+
+type auditService struct {
+	backend *pg.Store
+}
+`)},
+		"cmd/service.go": {Data: []byte(`package cmd
+
+import pg "example.com/shop/postgres"
+
+type MainService struct {
+	backend *pg.Store
+}
+`)},
+	}, ".", arctest.WithQualifiedNames(true))
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	if err := arch.ParsePackages(); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	application, err := arctest.NewLayer("application", "^application$")
+	if err != nil {
+		t.Fatalf("Failed to create application layer: %v", err)
+	}
+
+	fieldRule, err := arch.StructFieldsShouldUseInterfaces("^(application|cmd)\\..*Service$", ".*", ".*")
+	if err != nil {
+		t.Fatalf("Failed to create field rule: %v", err)
+	}
+	fieldRule.Layer = application
+	fieldRule.ExportedOnly = true
+	parameterRule, err := arch.MethodsShouldUseInterfaceParameters("^(application|cmd)\\..*Service$", ".*", ".*")
+	if err != nil {
+		t.Fatalf("Failed to create parameter rule: %v", err)
+	}
+	parameterRule.Layer = application
+	parameterRule.ExportedOnly = true
+
+	fields := arch.CheckStructFields([]*arctest.FieldRule{fieldRule})
+	parameters, err := arch.CheckMethodParameters([]*arctest.ParameterRule{parameterRule})
+	if err != nil {
+		t.Fatalf("Failed to check parameters: %v", err)
+	}
+
+	// Only the postgres store is a struct; d.Store resolves to the domain interface despite
+	// the struct of the same name, and unexported or out-of-layer structs are skipped
+	if len(fields) != 1 || !strings.Contains(fields[0], `Field "backend" of struct "OrderService"`) || !strings.Contains(fields[0], `"pg.Store"`) {
+		t.Fatalf("Expected only the backend field to be reported, got %v", fields)
+	}
+	if len(parameters) != 1 || !strings.Contains(parameters[0], `"pg.Store"`) {
+		t.Fatalf("Expected only the backend parameter to be reported, got %v", parameters)
+	}
+	t.Logf("  ✓ %s", fields[0])
+	t.Logf("  ✓ %s", parameters[0])
+}
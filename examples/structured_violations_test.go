@@ -0,0 +1,79 @@
+package examples
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mstrYoda/go-arctest/pkg/arctest"
+)
+
+// TestStructuredViolations demonstrates how to consume violations programmatically
+func TestStructuredViolations(t *testing.T) {
+	arch, err := arctest.New("./example_project")
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	if err := arch.ParsePackages("domain", "utils"); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	rule, err := arch.DoesNotDependOn("^domain$", ".*utils$")
+	if err != nil {
+		t.Fatalf("Failed to create dependency rule: %v", err)
+	}
+
+	valid, violations := arch.ValidateDependenciesWithRulesDetailed([]*arctest.DependencyRule{rule})
+	if valid || len(violations) != 1 {
+		t.Fatalf("Expected exactly one dependency violation, got %v", violations)
+	}
+
+	v := violations[0]
+	if v.RuleType != arctest.RuleTypeDependency {
+		t.Errorf("Unexpected rule type: %q", v.RuleType)
+	}
+	if v.SourcePackage != "domain" || !strings.HasSuffix(v.TargetPackage, "/utils") {
+		t.Errorf("Unexpected packages: %q -> %q", v.SourcePackage, v.TargetPackage)
+	}
+	if !strings.HasSuffix(v.File, "user_with_dependency_violation.go") || v.Line == 0 {
+		t.Errorf("Expected the violation to point at the import, got %s:%d", v.File, v.Line)
+	}
+
+	// The string-based API reports the same violation including its location
+	_, messages := arch.ValidateDependenciesWithRules([]*arctest.DependencyRule{rule})
+	if len(messages) != 1 || messages[0] != v.String() {
+		t.Errorf("Expected string violations to match structured ones, got %v", messages)
+	}
+}
+
+// TestWarningSeverity demonstrates staging in a rule without failing validation
+func TestWarningSeverity(t *testing.T) {
+	arch, err := arctest.New("./example_project")
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	if err := arch.ParsePackages("domain", "utils"); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+
+	rule, err := arch.DoesNotDependOn("^domain$", ".*utils$")
+	if err != nil {
+		t.Fatalf("Failed to create dependency rule: %v", err)
+	}
+	rule.Severity = arctest.SeverityWarning
+
+	valid, violations := arch.ValidateDependenciesWithRulesDetailed([]*arctest.DependencyRule{rule})
+	if !valid {
+		t.Errorf("Expected warnings not to fail validation")
+	}
+	if len(violations) != 1 || !violations[0].IsWarning() {
+		t.Fatalf("Expected exactly one warning, got %v", violations)
+	}
+	if arctest.HasErrors(violations) {
+		t.Errorf("Expected no error-level violations")
+	}
+	if !strings.Contains(violations[0].String(), "warning: ") {
+		t.Errorf("Expected warnings to be prefixed, got %q", violations[0].String())
+	}
+}
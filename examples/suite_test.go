@@ -0,0 +1,106 @@
+package examples
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mstrYoda/go-arctest/pkg/arctest"
+)
+
+// TestSuite demonstrates how to parse the project once and report all violations together
+func TestSuite(t *testing.T) {
+	suite, err := arctest.NewSuite("./example_project")
+	if err != nil {
+		t.Fatalf("Failed to create suite: %v", err)
+	}
+
+	domainLayer, err := arctest.NewLayer("Domain", "^domain$")
+	if err != nil {
+		t.Fatalf("Failed to create domain layer: %v", err)
+	}
+	utilsLayer, err := arctest.NewLayer("Utils", "^utils$")
+	if err != nil {
+		t.Fatalf("Failed to create utils layer: %v", err)
+	}
+
+	rule, err := suite.Arch.DoesNotDependOn("^domain$", ".*/utils$")
+	if err != nil {
+		t.Fatalf("Failed to create dependency rule: %v", err)
+	}
+
+	suite.
+		AddLayeredArchitecture(suite.Arch.NewLayeredArchitecture(domainLayer, utilsLayer)).
+		AddDependencyRules(rule).
+		AddCheck("No import cycles", func() []string {
+			_, violations := suite.Arch.HasNoCycles()
+			return violations
+		})
+
+	// The domain -> utils import violates both the layers and the dependency rule
+	recorder := &recordingT{}
+	suite.Run(recorder)
+	if len(recorder.errors) != 1 {
+		t.Fatalf("Expected a single combined report, got %v", recorder.errors)
+	}
+
+	report := recorder.errors[0]
+	for _, expected := range []string{
+		"2 architecture violation(s):",
+		"Layered architecture: Domain, Utils:\n  - ",
+		"Dependency rule: ^domain$ cannot import .*/utils$:\n  - ",
+	} {
+		if !strings.Contains(report, expected) {
+			t.Errorf("Expected the report to contain %q, got:\n%s", expected, report)
+		}
+	}
+	if strings.Contains(report, "No import cycles") {
+		t.Errorf("Expected rules without violations to be left out of the report, got:\n%s", report)
+	}
+	t.Logf("  ✓ %s", report)
+}
+
+// TestSuiteStats demonstrates how to find rules that match nothing
+func TestSuiteStats(t *testing.T) {
+	suite, err := arctest.NewSuite("./example_project")
+	if err != nil {
+		t.Fatalf("Failed to create suite: %v", err)
+	}
+
+	rule, err := suite.Arch.DoesNotDependOn("^domain$", ".*/utils$")
+	if err != nil {
+		t.Fatalf("Failed to create dependency rule: %v", err)
+	}
+
+	// The misspelled pattern doesn't match any package
+	typo, err := suite.Arch.DoesNotDependOn("^domian$", ".*/utils$")
+	if err != nil {
+		t.Fatalf("Failed to create dependency rule: %v", err)
+	}
+
+	suite.AddDependencyRules(rule, typo).AddCheck("No import cycles", func() []string {
+		_, violations := suite.Arch.HasNoCycles()
+		return violations
+	})
+
+	recorder := &recordingT{}
+	suite.Run(recorder)
+
+	stats := suite.Stats()
+	if len(stats) != 3 {
+		t.Fatalf("Expected statistics for every rule, got %v", stats)
+	}
+	if stats[0].Matches != 1 || stats[0].Violations != 1 {
+		t.Errorf("Expected the domain rule to match one package with one violation, got %+v", stats[0])
+	}
+	if stats[1].Matches != 0 || stats[1].Violations != 0 {
+		t.Errorf("Expected the misspelled rule to match nothing, got %+v", stats[1])
+	}
+	if stats[2].Matches >= 0 {
+		t.Errorf("Expected the matches of a custom check to be unknown, got %+v", stats[2])
+	}
+
+	if len(recorder.logs) != 1 || !strings.Contains(recorder.logs[0], "^domian$ cannot import .*/utils$:\n  - warning: rule matches nothing") {
+		t.Fatalf("Expected a warning about the misspelled rule, got %v", recorder.logs)
+	}
+	t.Logf("  ✓ %s", recorder.logs[0])
+}
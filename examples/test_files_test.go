@@ -0,0 +1,86 @@
+package examples
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/mstrYoda/go-arctest/pkg/arctest"
+)
+
+// TestIncludeTestFiles demonstrates how to apply architecture rules to test code
+func TestIncludeTestFiles(t *testing.T) {
+	// Test files are skipped by default
+	arch, err := arctest.New("./testdata/testfiles")
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+	if err := arch.ParsePackages(); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+	if _, found := arch.GetPackage("store").Structs["fakeClock"]; found {
+		t.Error("Expected test helpers to be skipped by default")
+	}
+	if arch.GetPackage("store_test") != nil {
+		t.Error("Expected the external test package to be skipped by default")
+	}
+
+	arch, err = arctest.New("./testdata/testfiles", arctest.WithTestFiles(true))
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+	if err := arch.ParsePackages(); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+	if _, found := arch.GetPackage("store").Structs["fakeClock"]; !found {
+		t.Error("Expected in-package test helpers to be merged into the package")
+	}
+
+	testPkg := arch.GetPackage("store_test")
+	if testPkg == nil || !testPkg.IsTest {
+		t.Fatal("Expected the external test package to be recorded separately")
+	}
+
+	// Test packages may only import the package under test
+	rule, err := arch.DoesNotDependOn("_test$", "^example.com/testfiles/(infrastructure|database)")
+	if err != nil {
+		t.Fatalf("Failed to create dependency rule: %v", err)
+	}
+	if valid, violations := arch.ValidateDependenciesWithRules([]*arctest.DependencyRule{rule}); !valid {
+		t.Errorf("Unexpected violations: %v", violations)
+	}
+}
+
+// TestMultiplePackagesInDirectory demonstrates that a directory declaring several packages is reported
+func TestMultiplePackagesInDirectory(t *testing.T) {
+	arch, err := arctest.NewFromFS(fstest.MapFS{
+		"go.mod":          {Data: []byte("module example.com/shop\n\ngo 1.20\n")},
+		"store/store.go":  {Data: []byte("package store\n\n// Store stores orders\ntype Store struct{}\n")},
+		"store/legacy.go": {Data: []byte("package legacy\n\n// Store is a leftover from a move\ntype Store struct{}\n")},
+	}, ".")
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+
+	err = arch.ParsePackages()
+	if err == nil || !strings.Contains(err.Error(), "found packages legacy (legacy.go) and store (store.go)") {
+		t.Fatalf("Expected the conflicting packages to be reported, got %v", err)
+	}
+	t.Logf("  ✓ %v", err)
+
+	// An external test package shares the directory with the package it tests
+	arch, err = arctest.NewFromFS(fstest.MapFS{
+		"go.mod":              {Data: []byte("module example.com/shop\n\ngo 1.20\n")},
+		"store/store.go":      {Data: []byte("package store\n\n// Store stores orders\ntype Store struct{}\n")},
+		"store/store_test.go": {Data: []byte("package store_test\n\n// fakeStore replaces the store in tests\ntype fakeStore struct{}\n")},
+	}, ".", arctest.WithTestFiles(true))
+	if err != nil {
+		t.Fatalf("Failed to create architecture: %v", err)
+	}
+	if err := arch.ParsePackages(); err != nil {
+		t.Fatalf("Failed to parse packages: %v", err)
+	}
+	if arch.GetPackage("store") == nil || arch.GetPackage("store_test") == nil {
+		t.Errorf("Expected the package and its external test package to be recorded separately")
+	}
+}
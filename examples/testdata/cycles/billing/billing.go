@@ -0,0 +1,8 @@
+package billing
+
+import "example.com/cycles/orders"
+
+// Invoice refers back to the order it bills
+type Invoice struct {
+	Order *orders.Order
+}
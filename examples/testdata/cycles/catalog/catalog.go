@@ -0,0 +1,6 @@
+package catalog
+
+// Product is sold to customers
+type Product struct {
+	Name string
+}
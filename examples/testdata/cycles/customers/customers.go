@@ -0,0 +1,8 @@
+package customers
+
+import "example.com/cycles/catalog"
+
+// Customer keeps a list of favourite products
+type Customer struct {
+	Favourites []catalog.Product
+}
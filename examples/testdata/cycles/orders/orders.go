@@ -0,0 +1,8 @@
+package orders
+
+import "example.com/cycles/billing"
+
+// Order is billed through an invoice
+type Order struct {
+	Invoice *billing.Invoice
+}
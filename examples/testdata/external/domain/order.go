@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+
+	"example.com/external/shared"
+	"github.com/google/uuid"
+	"golang.org/x/text/language"
+)
+
+// Order is a customer order
+type Order struct {
+	ID       shared.ID
+	Ref      uuid.UUID
+	Language language.Tag
+}
+
+// Marshal encodes the order
+func (o *Order) Marshal() ([]byte, error) {
+	return json.Marshal(o)
+}
+
+// Handler serves the order
+func (o *Order) Handler() http.Handler {
+	return http.FileServer(http.Dir(filepath.Join("orders", string(o.ID))))
+}
@@ -0,0 +1,8 @@
+package http
+
+import "net/http"
+
+// NewServer creates the HTTP server of the application
+func NewServer(handler http.Handler) *http.Server {
+	return &http.Server{Handler: handler}
+}
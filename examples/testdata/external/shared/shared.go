@@ -0,0 +1,4 @@
+package shared
+
+// ID identifies an entity
+type ID string
@@ -0,0 +1,6 @@
+package store
+
+// Store keeps values in memory
+type Store struct {
+	values map[string]string
+}
@@ -0,0 +1,4 @@
+package store
+
+// fakeClock is a test helper living in the package under test
+type fakeClock struct{}
@@ -0,0 +1,11 @@
+package store_test
+
+import (
+	"testing"
+
+	"example.com/testfiles/store"
+)
+
+func TestStore(t *testing.T) {
+	_ = store.Store{}
+}
@@ -1,50 +1,157 @@
 package arctest
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"go/ast"
+	"go/build"
 	"go/parser"
 	"go/token"
+	"io"
+	"io/fs"
 	"os"
+	"path"
 	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
 	"strings"
+	"sync"
 )
 
 // Architecture represents a collection of packages and their relationships
 type Architecture struct {
-	Packages map[string]*Package
-	basePath string
+	Packages       map[string]*Package
+	ModulePath     string           // module path declared in the go.mod enclosing the base path, if any
+	IncludeTests   bool             // if true, *_test.go files are parsed as well
+	Parallelism    int              // maximum number of directories parsed concurrently, GOMAXPROCS if zero
+	Logger         Logger           // receives verbose parse tracing, silent if nil
+	LooseMatching  bool             // if true, method signatures match by parameter and result count without comparing their types
+	BuildContext   build.Context    // files whose build constraints don't match this context are skipped
+	StrictPaths    bool             // if true, ParsePackages fails for paths that yield no package
+	QualifiedNames bool             // if true, struct and interface patterns of rules match "pkg/path.Name" instead of the bare name
+	fsys           fs.FS            // source tree the packages are read from
+	root           string           // slash-separated path of the analyzed directory within fsys
+	basePath       string           // absolute path of the analyzed directory, empty if not on disk
+	importBase     string           // import path corresponding to the base path
+	excludes       []string         // raw exclude patterns, compiled by New
+	exclude        []*regexp.Regexp // package paths matching any of these are not parsed
+	excluded       map[string]bool  // package paths skipped because they matched an exclude pattern
+	skipDirs       []string         // directory names or relative paths whose subtrees are not walked
+	cacheDir       string           // directory parse results are cached in, no caching if empty
+	mu             sync.Mutex       // guards Packages and excluded while parsing concurrently
+}
+
+// Logger receives verbose tracing output. Since packages are parsed concurrently,
+// implementations must be safe for concurrent use; *log.Logger satisfies this interface.
+type Logger interface {
+	Printf(format string, args ...interface{})
+}
+
+// Option configures an Architecture
+type Option func(*Architecture)
+
+// WithTestFiles makes the architecture parse *_test.go files. Test files of the package
+// itself are merged into the package, while external test packages (package foo_test)
+// are recorded separately under the package path with a "_test" suffix.
+func WithTestFiles(include bool) Option {
+	return func(a *Architecture) {
+		a.IncludeTests = include
+	}
 }
 
 // Package represents a Go package with its imports and types
 type Package struct {
 	Name         string
 	Path         string
+	IsTest       bool // true for external test packages (package foo_test)
 	Imports      []string
+	ImportSpecs  []*Import // import declarations with their positions, in the same order as Imports
 	Structs      map[string]*Struct
 	Interfaces   map[string]*Interface
-	ImportedPkgs map[string]string // map of alias -> package path
+	NamedTypes   map[string]*NamedType // defined types that are neither structs nor interfaces, e.g. type UserID string
+	TypeAliases  map[string]*NamedType // alias declarations, e.g. type Handler = http.HandlerFunc
+	Functions    []*Function           // package-level functions without a receiver
+	Variables    []*Variable           // package-level var declarations
+	Constants    []*Variable           // package-level const declarations
+	Calls        []*Call               // function and method calls, including those inside closures
+	ImportedPkgs map[string]string     // map of alias -> package path
+	Ignores      []*IgnoreDirective    // //arctest:ignore comments of the package's files
+	Fset         *token.FileSet        `json:"-"` // file set the package was parsed with, empty if loaded from the cache
+	arch         *Architecture         // architecture the package was parsed into
+}
+
+// ImportKind describes how a package is imported
+type ImportKind string
+
+const (
+	// ImportDefault is an import without an explicit name
+	ImportDefault ImportKind = "default"
+	// ImportNamed is an import with an explicit name, e.g. pg "github.com/lib/pq"
+	ImportNamed ImportKind = "named"
+	// ImportDot is a dot-import, which merges the imported identifiers into the file scope
+	ImportDot ImportKind = "dot"
+	// ImportBlank is a blank import, only imported for its side effects
+	ImportBlank ImportKind = "blank"
+)
+
+// Import represents an import declaration
+type Import struct {
+	Path     string
+	Alias    string     // explicit import name, empty if none
+	Kind     ImportKind // how the package is imported
+	Position token.Position
 }
 
 // Struct represents a Go struct with its fields and methods
 type Struct struct {
-	Name    string
-	Fields  []*Field
-	Methods []*Method
-	Pkg     *Package
+	Name     string
+	Fields   []*Field
+	Methods  []*Method // methods declared on the struct itself, without promoted ones
+	Embeds   []string  // types of embedded fields, e.g. "BaseRepo", "*BaseRepo" or "db.Conn"
+	Pkg      *Package  `json:"-"`
+	Position token.Position
+}
+
+// NamedType represents a type declaration other than a struct or interface declaration,
+// or a type alias
+type NamedType struct {
+	Name       string
+	Underlying string   // type the declaration refers to, e.g. "string" or "http.HandlerFunc"
+	Alias      bool     // true for alias declarations (type A = B)
+	Pkg        *Package `json:"-"`
+	Position   token.Position
 }
 
 // Field represents a struct field
 type Field struct {
-	Name string
-	Type string
+	Name     string
+	Type     string
+	Embedded bool // true for embedded fields, whose name is the name of their type
+	Position token.Position
 }
 
 // Method represents a struct method
 type Method struct {
 	Name       string
 	Params     []*Parameter
-	ReturnType string
+	Returns    []*Parameter // results in declaration order, named or unnamed
+	ReturnType string       // comma-separated result types, empty if the method returns nothing
+	// PointerReceiver is true for methods declared on *T, which are not part of the
+	// method set of the value type T
+	PointerReceiver bool
+	Position        token.Position
+}
+
+// Function represents a package-level function declared without a receiver
+type Function struct {
+	Name       string
+	Params     []*Parameter
+	Returns    []*Parameter // results in declaration order, named or unnamed
+	ReturnType string       // comma-separated result types, empty if the function returns nothing
+	Pkg        *Package     `json:"-"`
+	Position   token.Position
 }
 
 // Parameter represents a method parameter
@@ -53,102 +160,509 @@ type Parameter struct {
 	Type string
 }
 
+// Variable represents a package-level var or const declaration
+type Variable struct {
+	Name     string
+	Type     string // explicit or inherited type, empty if inferred from the value
+	Const    bool
+	Pkg      *Package `json:"-"`
+	Position token.Position
+}
+
 // Interface represents a Go interface with its methods
 type Interface struct {
-	Name    string
-	Methods []*Method
-	Pkg     *Package
+	Name     string
+	Methods  []*Method
+	Pkg      *Package `json:"-"`
+	Position token.Position
+}
+
+// WithLogger enables verbose parse tracing through the given logger
+func WithLogger(logger Logger) Option {
+	return func(a *Architecture) {
+		a.Logger = logger
+	}
+}
+
+// logf writes a trace message if a logger is configured
+func (a *Architecture) logf(format string, args ...interface{}) {
+	if a.Logger != nil {
+		a.Logger.Printf(format, args...)
+	}
+}
+
+// WithParallelism limits the number of directories parsed concurrently when parsing
+// all packages. A value of zero or less uses GOMAXPROCS.
+func WithParallelism(workers int) Option {
+	return func(a *Architecture) {
+		a.Parallelism = workers
+	}
+}
+
+// WithExclude skips packages whose path matches any of the given regex patterns, e.g.
+// generated code or mocks. Excluded packages are not parsed and not subject to any rule,
+// but imports of them are still resolved, so they can be dependency targets.
+func WithExclude(patterns ...string) Option {
+	return func(a *Architecture) {
+		a.excludes = append(a.excludes, patterns...)
+	}
+}
+
+// WithSkipDirs skips the given directories and their subtrees when looking for packages,
+// like vendor directories are. A plain name such as "testdata" matches directories of that
+// name anywhere in the tree, a slash-separated path such as "internal/gen" matches the
+// directory at that path relative to the base path.
+func WithSkipDirs(dirs ...string) Option {
+	return func(a *Architecture) {
+		a.skipDirs = append(a.skipDirs, dirs...)
+	}
+}
+
+// WithStrictPaths determines whether ParsePackages returns an error for a path that
+// yields no package at all, which usually means the path has a typo. This is the
+// default; disable it when passing paths that may legitimately be empty.
+func WithStrictPaths(strict bool) Option {
+	return func(a *Architecture) {
+		a.StrictPaths = strict
+	}
+}
+
+// WithLooseMatching makes interface implementation checks only compare the number of
+// parameters and results of methods rather than their types, as in earlier versions
+func WithLooseMatching(loose bool) Option {
+	return func(a *Architecture) {
+		a.LooseMatching = loose
+	}
+}
+
+// WithQualifiedNames makes the struct and interface patterns of interface, parameter and
+// field rules, as well as the interface patterns of InterfaceMustBeImplemented and
+// MustContainAllImplementationsOf, match fully-qualified names such as
+// "infrastructure/postgres.UserRepository", which disambiguates types of the same name
+// declared in different packages
+func WithQualifiedNames(qualified bool) Option {
+	return func(a *Architecture) {
+		a.QualifiedNames = qualified
+	}
+}
+
+// WithBuildContext sets the build context source files are matched against. Files whose
+// //go:build constraints or _GOOS/_GOARCH file name suffixes don't match the context's
+// GOOS, GOARCH and build tags are skipped. The default is build.Default, the host's context.
+func WithBuildContext(ctx build.Context) Option {
+	return func(a *Architecture) {
+		a.BuildContext = ctx
+	}
 }
 
 // New creates a new Architecture instance for the given base path
-func New(basePath string) (*Architecture, error) {
+func New(basePath string, opts ...Option) (*Architecture, error) {
 	abs, err := filepath.Abs(basePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get absolute path: %w", err)
 	}
 
-	return &Architecture{
-		Packages: make(map[string]*Package),
-		basePath: abs,
-	}, nil
+	a, err := newArchitecture(os.DirFS(abs), ".", opts)
+	if err != nil {
+		return nil, err
+	}
+	a.basePath = abs
+
+	if err := a.resolveModule(); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// NewFromFS creates a new Architecture instance for the directory root within fsys, which
+// allows analyzing source trees that aren't on disk, e.g. an fstest.MapFS. The module path
+// is read from a go.mod in root or one of its parents within fsys.
+func NewFromFS(fsys fs.FS, root string, opts ...Option) (*Architecture, error) {
+	if !fs.ValidPath(root) {
+		return nil, fmt.Errorf("invalid root %q", root)
+	}
+
+	a, err := newArchitecture(fsys, root, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := a.resolveModule(); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// resolveModule reads the module path from the go.mod enclosing the base path and derives
+// the import path of the base path from it
+func (a *Architecture) resolveModule() error {
+	a.ModulePath, a.importBase = "", ""
+
+	if a.basePath != "" {
+		modulePath, moduleRoot, err := findModule(a.basePath)
+		if err != nil {
+			return err
+		}
+		if modulePath != "" {
+			rel, err := filepath.Rel(moduleRoot, a.basePath)
+			if err != nil {
+				return fmt.Errorf("failed to resolve base path against module root: %w", err)
+			}
+			a.ModulePath = modulePath
+			a.importBase = path.Join(modulePath, filepath.ToSlash(rel))
+		}
+		return nil
+	}
+
+	modulePath, moduleRoot, err := findModuleFS(a.fsys, a.root)
+	if err != nil {
+		return err
+	}
+	if modulePath != "" {
+		rel := "."
+		if moduleRoot == "." {
+			rel = a.root
+		} else if a.root != moduleRoot {
+			rel = strings.TrimPrefix(a.root, moduleRoot+"/")
+		}
+		a.ModulePath = modulePath
+		a.importBase = path.Join(modulePath, rel)
+	}
+	return nil
+}
+
+// Reset forgets all parsed packages while keeping the base path and options, so that
+// ParsePackages picks up changes to the source tree when called again, e.g. in a watch
+// mode. The module path is read again, since go.mod may have changed as well.
+func (a *Architecture) Reset() error {
+	a.mu.Lock()
+	a.Packages = make(map[string]*Package)
+	a.excluded = make(map[string]bool)
+	a.mu.Unlock()
+
+	return a.resolveModule()
+}
+
+// newArchitecture creates an Architecture reading from fsys and applies the options
+func newArchitecture(fsys fs.FS, root string, opts []Option) (*Architecture, error) {
+	a := &Architecture{
+		Packages:     make(map[string]*Package),
+		BuildContext: build.Default,
+		StrictPaths:  true,
+		fsys:         fsys,
+		root:         root,
+		excluded:     make(map[string]bool),
+	}
+	for _, opt := range opts {
+		opt(a)
+	}
+
+	for _, pattern := range a.excludes {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exclude pattern %q: %w", pattern, err)
+		}
+		a.exclude = append(a.exclude, re)
+	}
+
+	return a, nil
+}
+
+// findModule looks for a go.mod file in dir or any of its parents and returns the
+// declared module path and the directory containing go.mod.
+// Empty strings are returned if no go.mod is found.
+func findModule(dir string) (string, string, error) {
+	for {
+		data, err := os.ReadFile(filepath.Join(dir, "go.mod"))
+		if err == nil {
+			return parseModulePath(data), dir, nil
+		}
+		if !os.IsNotExist(err) {
+			return "", "", fmt.Errorf("failed to read go.mod: %w", err)
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", "", nil
+		}
+		dir = parent
+	}
+}
+
+// findModuleFS looks for a go.mod file in dir or any of its parents within fsys and
+// returns the declared module path and the directory containing go.mod.
+// Empty strings are returned if no go.mod is found.
+func findModuleFS(fsys fs.FS, dir string) (string, string, error) {
+	for {
+		data, err := fs.ReadFile(fsys, path.Join(dir, "go.mod"))
+		if err == nil {
+			return parseModulePath(data), dir, nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return "", "", fmt.Errorf("failed to read go.mod: %w", err)
+		}
+
+		if dir == "." {
+			return "", "", nil
+		}
+		dir = path.Dir(dir)
+	}
+}
+
+// parseModulePath extracts the module path from the contents of a go.mod file
+func parseModulePath(data []byte) string {
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "module") {
+			continue
+		}
+
+		modulePath := strings.TrimSpace(strings.TrimPrefix(line, "module"))
+		if idx := strings.Index(modulePath, "//"); idx >= 0 {
+			modulePath = strings.TrimSpace(modulePath[:idx])
+		}
+		return strings.Trim(modulePath, "\"`")
+	}
+	return ""
+}
+
+// ImportPath returns the full import path of a parsed package path, using the module
+// path from go.mod. The package path is returned unchanged if no module was found.
+func (a *Architecture) ImportPath(pkgPath string) string {
+	if a.importBase == "" {
+		return pkgPath
+	}
+	return path.Join(a.importBase, filepath.ToSlash(pkgPath))
+}
+
+// ResolveImport maps an import path to the path of a parsed package. The second result
+// is false if the import does not refer to a package in the architecture.
+func (a *Architecture) ResolveImport(importPath string) (string, bool) {
+	if _, ok := a.Packages[importPath]; ok {
+		return importPath, true
+	}
+
+	// Map imports of the module path and below it back to package paths, the root
+	// package being "."
+	if a.importBase != "" {
+		pkgPath := "."
+		if importPath != a.importBase {
+			if !strings.HasPrefix(importPath, a.importBase+"/") {
+				return "", false
+			}
+			pkgPath = filepath.FromSlash(strings.TrimPrefix(importPath, a.importBase+"/"))
+		}
+		if _, ok := a.Packages[pkgPath]; ok || a.excluded[pkgPath] {
+			return pkgPath, true
+		}
+		return "", false
+	}
+
+	// Without a module path, fall back to matching the end of the import path. The longest
+	// match wins, so that .../domain/user resolves to domain/user rather than user.
+	best := ""
+	consider := func(pkgPath string) {
+		if !strings.HasSuffix(importPath, "/"+filepath.ToSlash(pkgPath)) {
+			return
+		}
+		if len(pkgPath) > len(best) || (len(pkgPath) == len(best) && pkgPath < best) {
+			best = pkgPath
+		}
+	}
+	for pkgPath := range a.Packages {
+		consider(pkgPath)
+	}
+	for pkgPath := range a.excluded {
+		consider(pkgPath)
+	}
+
+	return best, best != ""
 }
 
 // ParsePackages parses all packages in the architecture
 func (a *Architecture) ParsePackages(pkgPaths ...string) error {
+	return a.ParsePackagesContext(context.Background(), pkgPaths...)
+}
+
+// ParsePackagesContext parses packages like ParsePackages, checking the context between
+// packages. Once the context is done, no further packages are parsed and its error is
+// returned; packages parsed until then remain in Packages.
+func (a *Architecture) ParsePackagesContext(ctx context.Context, pkgPaths ...string) error {
 	if len(pkgPaths) == 0 {
 		// If no paths specified, parse all packages in the base path
-		return a.parseAllPackages()
+		return a.parseAllPackages(ctx)
 	}
 
 	for _, path := range pkgPaths {
-		if err := a.ParsePackage(path); err != nil {
+		if err := a.parsePackage(ctx, path); err != nil {
 			return err
 		}
+		if a.StrictPaths && !a.hasPackagesBelow(path) {
+			return fmt.Errorf("no Go packages found in %s", path)
+		}
 	}
 
 	return nil
 }
 
-func (a *Architecture) parseAllPackages() error {
-	return filepath.Walk(a.basePath, func(path string, info os.FileInfo, err error) error {
+// hasPackagesBelow checks if a package was parsed or excluded at the given path or in
+// one of its subdirectories. Paths of Go files refer to the directory containing them.
+func (a *Architecture) hasPackagesBelow(pkgPath string) bool {
+	pkgPath = filepath.Clean(pkgPath)
+	if strings.HasSuffix(pkgPath, ".go") {
+		pkgPath = filepath.Dir(pkgPath)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	below := func(p string) bool {
+		p = strings.TrimSuffix(p, "_test")
+		return pkgPath == "." || p == pkgPath || strings.HasPrefix(p, pkgPath+string(filepath.Separator))
+	}
+	for p := range a.Packages {
+		if below(p) {
+			return true
+		}
+	}
+	for p := range a.excluded {
+		if below(p) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseAllPackages finds every directory below the base path that contains Go files
+// and parses them concurrently
+func (a *Architecture) parseAllPackages(ctx context.Context) error {
+	dirs := []string{}
+	err := fs.WalkDir(a.fsys, a.root, func(dir string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 
-		if info.IsDir() && !strings.HasPrefix(info.Name(), ".") {
-			relPath, err := filepath.Rel(a.basePath, path)
-			if err != nil {
-				return err
+		if d.IsDir() {
+			relPath := a.relPath(dir)
+
+			// Skip hidden directories such as .git without descending into them
+			if relPath != "." && strings.HasPrefix(d.Name(), ".") {
+				return fs.SkipDir
 			}
 
 			// Skip vendor directory and non-Go packages
 			if relPath == "vendor" || strings.HasPrefix(relPath, "vendor/") {
-				return filepath.SkipDir
+				return fs.SkipDir
+			}
+
+			if relPath != "." && a.isSkippedDir(relPath) {
+				a.logf("Skipping directory %s", relPath)
+				return fs.SkipDir
 			}
 
 			// Check if directory contains .go files
-			hasGoFiles := false
-			files, err := os.ReadDir(path)
+			files, err := fs.ReadDir(a.fsys, dir)
 			if err != nil {
 				return err
 			}
 
-			for _, file := range files {
-				if !file.IsDir() && strings.HasSuffix(file.Name(), ".go") && !strings.HasSuffix(file.Name(), "_test.go") {
-					hasGoFiles = true
-					break
-				}
+			if a.hasSourceFiles(files) {
+				dirs = append(dirs, filepath.FromSlash(relPath))
 			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return a.parseDirs(ctx, dirs)
+}
 
-			if hasGoFiles {
-				if err := a.ParsePackage(relPath); err != nil {
-					return err
+// parseDirs parses the given package directories using a pool of workers bounded by
+// the architecture's parallelism. The first error or the context being done stops the
+// remaining work and is returned.
+func (a *Architecture) parseDirs(ctx context.Context, dirs []string) error {
+	workers := a.Parallelism
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > len(dirs) {
+		workers = len(dirs)
+	}
+
+	jobs := make(chan string)
+	done := make(chan struct{})
+	var firstErr error
+	var errOnce sync.Once
+	var wg sync.WaitGroup
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for relPath := range jobs {
+				if err := a.parsePackageDir(relPath); err != nil {
+					errOnce.Do(func() {
+						firstErr = err
+						close(done)
+					})
 				}
 			}
+		}()
+	}
+
+	// Hand out directories until they are exhausted, a worker failed or the context is done
+feed:
+	for _, relPath := range dirs {
+		select {
+		case jobs <- relPath:
+		case <-done:
+			break feed
+		case <-ctx.Done():
+			break feed
 		}
+	}
+	close(jobs)
+	wg.Wait()
 
-		return nil
-	})
+	if firstErr != nil {
+		return firstErr
+	}
+	return ctx.Err()
 }
 
 // ParsePackage parses a specific package and its subpackages
 func (a *Architecture) ParsePackage(pkgPath string) error {
-	fullPath := filepath.Join(a.basePath, pkgPath)
+	return a.parsePackage(context.Background(), pkgPath)
+}
+
+// parsePackage parses a package and its subpackages, checking the context before each package
+func (a *Architecture) parsePackage(ctx context.Context, pkgPath string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	fullPath := a.fsPath(pkgPath)
 
 	// First check if this is a directory
-	info, err := os.Stat(fullPath)
+	info, err := fs.Stat(a.fsys, fullPath)
 	if err != nil {
 		return fmt.Errorf("failed to stat package path %s: %w", pkgPath, err)
 	}
 
 	if info.IsDir() {
 		// Parse the current directory as a package
-		if err := a.parsePackageDir(fullPath, pkgPath); err != nil {
+		if err := a.parsePackageDir(pkgPath); err != nil {
 			return err
 		}
 
 		// Now recursively parse all subdirectories that might contain Go packages
-		files, err := os.ReadDir(fullPath)
+		files, err := fs.ReadDir(a.fsys, fullPath)
 		if err != nil {
 			return fmt.Errorf("failed to read package directory %s: %w", pkgPath, err)
 		}
@@ -156,23 +670,19 @@ func (a *Architecture) ParsePackage(pkgPath string) error {
 		for _, file := range files {
 			if file.IsDir() && !strings.HasPrefix(file.Name(), ".") {
 				subPkgPath := filepath.Join(pkgPath, file.Name())
+				if a.isSkippedDir(filepath.ToSlash(subPkgPath)) {
+					a.logf("Skipping directory %s", subPkgPath)
+					continue
+				}
+
 				// Check if the subdirectory contains any Go files before parsing
-				hasGoFiles := false
-				subDir := filepath.Join(fullPath, file.Name())
-				subFiles, err := os.ReadDir(subDir)
+				subFiles, err := fs.ReadDir(a.fsys, path.Join(fullPath, file.Name()))
 				if err != nil {
 					return fmt.Errorf("failed to read subdirectory %s: %w", subPkgPath, err)
 				}
 
-				for _, subFile := range subFiles {
-					if !subFile.IsDir() && strings.HasSuffix(subFile.Name(), ".go") && !strings.HasSuffix(subFile.Name(), "_test.go") {
-						hasGoFiles = true
-						break
-					}
-				}
-
-				if hasGoFiles {
-					if err := a.ParsePackage(subPkgPath); err != nil {
+				if a.hasSourceFiles(subFiles) {
+					if err := a.parsePackage(ctx, subPkgPath); err != nil {
 						return err
 					}
 				}
@@ -182,50 +692,271 @@ func (a *Architecture) ParsePackage(pkgPath string) error {
 	}
 
 	// If it's not a directory, assume it's a Go file or pattern
-	return a.parsePackageDir(filepath.Dir(fullPath), filepath.Dir(pkgPath))
+	return a.parsePackageDir(filepath.Dir(pkgPath))
+}
+
+// isSkippedDir checks if a directory, given by its slash-separated path relative to the
+// base path, matches one of the directories to skip
+func (a *Architecture) isSkippedDir(relPath string) bool {
+	name := path.Base(relPath)
+	for _, dir := range a.skipDirs {
+		dir = strings.Trim(filepath.ToSlash(dir), "/")
+		if strings.Contains(dir, "/") {
+			if relPath == dir || strings.HasPrefix(relPath, dir+"/") {
+				return true
+			}
+		} else if name == dir {
+			return true
+		}
+	}
+	return false
+}
+
+// fsPath returns the slash-separated path of a package directory within fsys
+func (a *Architecture) fsPath(pkgPath string) string {
+	return path.Join(a.root, filepath.ToSlash(pkgPath))
+}
+
+// relPath returns the slash-separated path of a directory within fsys relative to the root
+func (a *Architecture) relPath(dir string) string {
+	switch {
+	case dir == a.root:
+		return "."
+	case a.root == ".":
+		return dir
+	default:
+		return strings.TrimPrefix(dir, a.root+"/")
+	}
+}
+
+// fileName returns the name positions in a source file are reported with: the absolute
+// path for trees on disk, the path within fsys otherwise
+func (a *Architecture) fileName(pkgPath, name string) string {
+	if a.basePath != "" {
+		return filepath.Join(a.basePath, pkgPath, name)
+	}
+	return path.Join(a.fsPath(pkgPath), name)
+}
+
+// hasSourceFiles checks if any of the directory entries is a file that should be parsed
+func (a *Architecture) hasSourceFiles(entries []fs.DirEntry) bool {
+	for _, entry := range entries {
+		if !entry.IsDir() && a.isSourceFile(entry.Name()) {
+			return true
+		}
+	}
+	return false
+}
+
+// embeddedFieldName returns the implicit name of an embedded field of the given type,
+// e.g. "BaseRepo" for "*db.BaseRepo"
+func embeddedFieldName(fieldType string) string {
+	name := strings.TrimPrefix(fieldType, "*")
+	if dot := strings.LastIndex(name, "."); dot >= 0 {
+		name = name[dot+1:]
+	}
+	return name
+}
+
+// isSourceFile checks if a file should be parsed, taking IncludeTests into account
+func (a *Architecture) isSourceFile(name string) bool {
+	if !strings.HasSuffix(name, ".go") {
+		return false
+	}
+	return a.IncludeTests || !strings.HasSuffix(name, "_test.go")
+}
+
+// matchBuildContext checks if a source file in the given fsys directory satisfies the
+// build constraints of the architecture's build context
+func (a *Architecture) matchBuildContext(dir, name string) (bool, error) {
+	ctx := a.BuildContext
+	ctx.JoinPath = path.Join
+	ctx.OpenFile = func(name string) (io.ReadCloser, error) {
+		return a.fsys.Open(name)
+	}
+	return ctx.MatchFile(dir, name)
 }
 
 // parsePackageDir parses a specific directory as a Go package
-func (a *Architecture) parsePackageDir(fullPath, pkgPath string) error {
-	fset := token.NewFileSet()
-	pkgs, err := parser.ParseDir(fset, fullPath, func(info os.FileInfo) bool {
-		return !strings.HasSuffix(info.Name(), "_test.go")
-	}, parser.ParseComments)
+func (a *Architecture) parsePackageDir(pkgPath string) error {
+	if a.isExcluded(pkgPath) {
+		a.logf("Skipping excluded package %s", pkgPath)
+		a.mu.Lock()
+		a.excluded[pkgPath] = true
+		a.mu.Unlock()
+		return nil
+	}
 
+	dir := a.fsPath(pkgPath)
+	entries, err := fs.ReadDir(a.fsys, dir)
 	if err != nil {
 		return fmt.Errorf("failed to parse package %s: %w", pkgPath, err)
 	}
 
-	for pkgName, pkg := range pkgs {
+	cacheKey := a.cacheKey(entries)
+	if cached, ok := a.loadCache(pkgPath, cacheKey); ok {
+		a.logf("Loaded package %s from cache", pkgPath)
+		a.storePackages(cached)
+		return nil
+	}
+
+	// Group the files of the directory by package name, since external test
+	// packages share the directory with the package they test
+	fset := token.NewFileSet()
+	pkgs := make(map[string][]*ast.File)
+	ignores := make(map[*ast.File][]*IgnoreDirective)
+	for _, entry := range entries {
+		if entry.IsDir() || !a.isSourceFile(entry.Name()) {
+			continue
+		}
+
+		match, err := a.matchBuildContext(dir, entry.Name())
+		if err != nil {
+			return fmt.Errorf("failed to parse package %s: %w", pkgPath, err)
+		}
+		if !match {
+			a.logf("Skipping %s in %s: build constraints exclude it", entry.Name(), pkgPath)
+			continue
+		}
+
+		src, err := fs.ReadFile(a.fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return fmt.Errorf("failed to parse package %s: %w", pkgPath, err)
+		}
+
+		file, err := parser.ParseFile(fset, a.fileName(pkgPath, entry.Name()), src, parser.ParseComments)
+		if err != nil {
+			return fmt.Errorf("failed to parse package %s: %w", pkgPath, err)
+		}
+		pkgs[file.Name.Name] = append(pkgs[file.Name.Name], file)
+		ignores[file] = parseIgnoreDirectives(fset, file, src)
+	}
+	a.logf("Parsed directory %s: found %d package(s)", pkgPath, len(pkgs))
+
+	// Like the go command, refuse directories whose files declare several packages, since
+	// they would be recorded under the same path and overwrite each other
+	names := make([]string, 0, len(pkgs))
+	for pkgName := range pkgs {
+		names = append(names, pkgName)
+	}
+	sort.Strings(names)
+	first := make(map[bool]string) // first package name seen, by whether it is a test package
+	for _, pkgName := range names {
+		isTest := strings.HasSuffix(pkgName, "_test")
+		if other, found := first[isTest]; found {
+			return fmt.Errorf("failed to parse package %s: found packages %s (%s) and %s (%s)", pkgPath,
+				other, filepath.Base(fset.Position(pkgs[other][0].Package).Filename),
+				pkgName, filepath.Base(fset.Position(pkgs[pkgName][0].Package).Filename))
+		}
+		first[isTest] = pkgName
+	}
+
+	parsed := make([]*Package, 0, len(pkgs))
+	for pkgName, files := range pkgs {
+		// External test packages are kept apart from the package they test
+		isTest := strings.HasSuffix(pkgName, "_test")
+		storedPath := pkgPath
+		if isTest {
+			storedPath = pkgPath + "_test"
+		}
+
 		p := &Package{
 			Name:         pkgName,
-			Path:         pkgPath,
+			Path:         storedPath,
+			IsTest:       isTest,
 			Imports:      make([]string, 0),
+			ImportSpecs:  make([]*Import, 0),
 			Structs:      make(map[string]*Struct),
 			Interfaces:   make(map[string]*Interface),
+			NamedTypes:   make(map[string]*NamedType),
+			TypeAliases:  make(map[string]*NamedType),
+			Functions:    make([]*Function, 0),
+			Variables:    make([]*Variable, 0),
+			Constants:    make([]*Variable, 0),
+			Calls:        make([]*Call, 0),
 			ImportedPkgs: make(map[string]string),
+			Ignores:      make([]*IgnoreDirective, 0),
+			Fset:         fset,
+			arch:         a,
 		}
 
-		for _, file := range pkg.Files {
+		for _, file := range files {
+			p.Ignores = append(p.Ignores, ignores[file]...)
+
 			// Process imports
 			for _, imp := range file.Imports {
 				importPath := strings.Trim(imp.Path.Value, "\"")
 				p.Imports = append(p.Imports, importPath)
+				a.logf("Found import in %s: %s", storedPath, importPath)
 
 				// Handle import alias
 				var alias string
+				spec := &Import{
+					Path:     importPath,
+					Kind:     ImportDefault,
+					Position: fset.Position(imp.Pos()),
+				}
 				if imp.Name != nil {
 					alias = imp.Name.Name
+					spec.Alias = alias
+					switch alias {
+					case ".":
+						spec.Kind = ImportDot
+					case "_":
+						spec.Kind = ImportBlank
+					default:
+						spec.Kind = ImportNamed
+					}
 				} else {
 					parts := strings.Split(importPath, "/")
 					alias = parts[len(parts)-1]
 				}
 				p.ImportedPkgs[alias] = importPath
+				p.ImportSpecs = append(p.ImportSpecs, spec)
 			}
 
 			// Process declarations
 			for _, decl := range file.Decls {
 				genDecl, ok := decl.(*ast.GenDecl)
+				if ok && (genDecl.Tok == token.VAR || genDecl.Tok == token.CONST) {
+					isConst := genDecl.Tok == token.CONST
+					// Within a const block a spec without type and values repeats
+					// the previous spec, which is how iota sequences keep their type
+					lastType := ""
+					for _, spec := range genDecl.Specs {
+						valueSpec, ok := spec.(*ast.ValueSpec)
+						if !ok {
+							continue
+						}
+
+						varType := exprToTypeString(valueSpec.Type)
+
+						if isConst {
+							if valueSpec.Type == nil && len(valueSpec.Values) == 0 {
+								varType = lastType
+							}
+							lastType = varType
+						}
+
+						// Handle multiple names in the same spec
+						for _, name := range valueSpec.Names {
+							v := &Variable{
+								Name:     name.Name,
+								Type:     varType,
+								Const:    isConst,
+								Pkg:      p,
+								Position: fset.Position(name.Pos()),
+							}
+							if isConst {
+								p.Constants = append(p.Constants, v)
+							} else {
+								p.Variables = append(p.Variables, v)
+							}
+						}
+					}
+					continue
+				}
+
 				if ok && genDecl.Tok == token.TYPE {
 					for _, spec := range genDecl.Specs {
 						typeSpec, ok := spec.(*ast.TypeSpec)
@@ -237,41 +968,36 @@ func (a *Architecture) parsePackageDir(fullPath, pkgPath string) error {
 						structType, isStruct := typeSpec.Type.(*ast.StructType)
 						if isStruct {
 							s := &Struct{
-								Name:    typeSpec.Name.Name,
-								Fields:  make([]*Field, 0),
-								Methods: make([]*Method, 0),
-								Pkg:     p,
+								Name:     typeSpec.Name.Name,
+								Fields:   make([]*Field, 0),
+								Methods:  make([]*Method, 0),
+								Pkg:      p,
+								Position: fset.Position(typeSpec.Pos()),
 							}
 
 							// Process struct fields
 							if structType.Fields != nil {
 								for _, field := range structType.Fields.List {
-									fieldType := ""
-									// Get field type as string
-									switch t := field.Type.(type) {
-									case *ast.Ident:
-										fieldType = t.Name
-									case *ast.SelectorExpr:
-										if x, ok := t.X.(*ast.Ident); ok {
-											fieldType = x.Name + "." + t.Sel.Name
-										}
-									case *ast.StarExpr:
-										// Handle pointer types
-										switch pt := t.X.(type) {
-										case *ast.Ident:
-											fieldType = "*" + pt.Name
-										case *ast.SelectorExpr:
-											if x, ok := pt.X.(*ast.Ident); ok {
-												fieldType = "*" + x.Name + "." + pt.Sel.Name
-											}
-										}
+									fieldType := exprToTypeString(field.Type)
+
+									// Embedded fields are named after their type
+									if len(field.Names) == 0 {
+										s.Embeds = append(s.Embeds, fieldType)
+										s.Fields = append(s.Fields, &Field{
+											Name:     embeddedFieldName(fieldType),
+											Type:     fieldType,
+											Embedded: true,
+											Position: fset.Position(field.Pos()),
+										})
+										continue
 									}
 
 									// Handle multiple names for the same type
 									for _, name := range field.Names {
 										s.Fields = append(s.Fields, &Field{
-											Name: name.Name,
-											Type: fieldType,
+											Name:     name.Name,
+											Type:     fieldType,
+											Position: fset.Position(name.Pos()),
 										})
 									}
 								}
@@ -284,9 +1010,10 @@ func (a *Architecture) parsePackageDir(fullPath, pkgPath string) error {
 						interfaceType, isInterface := typeSpec.Type.(*ast.InterfaceType)
 						if isInterface {
 							i := &Interface{
-								Name:    typeSpec.Name.Name,
-								Methods: make([]*Method, 0),
-								Pkg:     p,
+								Name:     typeSpec.Name.Name,
+								Methods:  make([]*Method, 0),
+								Pkg:      p,
+								Position: fset.Position(typeSpec.Pos()),
 							}
 
 							// Process interface methods
@@ -297,57 +1024,14 @@ func (a *Architecture) parsePackageDir(fullPath, pkgPath string) error {
 										continue
 									}
 
+									// Process method parameters and return types
 									m := &Method{
-										Name:       method.Names[0].Name,
-										Params:     make([]*Parameter, 0),
-										ReturnType: "",
-									}
-
-									// Process method parameters
-									if funcType.Params != nil {
-										for _, param := range funcType.Params.List {
-											paramType := ""
-											switch t := param.Type.(type) {
-											case *ast.Ident:
-												paramType = t.Name
-											case *ast.SelectorExpr:
-												if x, ok := t.X.(*ast.Ident); ok {
-													paramType = x.Name + "." + t.Sel.Name
-												}
-											case *ast.StarExpr:
-												// Handle pointer types
-												switch pt := t.X.(type) {
-												case *ast.Ident:
-													paramType = "*" + pt.Name
-												case *ast.SelectorExpr:
-													if x, ok := pt.X.(*ast.Ident); ok {
-														paramType = "*" + x.Name + "." + pt.Sel.Name
-													}
-												}
-											}
-
-											// Handle multiple names for the same type
-											if len(param.Names) == 0 {
-												m.Params = append(m.Params, &Parameter{
-													Name: "",
-													Type: paramType,
-												})
-											} else {
-												for _, name := range param.Names {
-													m.Params = append(m.Params, &Parameter{
-														Name: name.Name,
-														Type: paramType,
-													})
-												}
-											}
-										}
-									}
-
-									// Process return types
-									if funcType.Results != nil && funcType.Results.List != nil {
-										// For simplicity, just note if there's a return value
-										m.ReturnType = "has_return"
+										Name:     method.Names[0].Name,
+										Params:   parseFieldList(funcType.Params),
+										Returns:  parseFieldList(funcType.Results),
+										Position: fset.Position(method.Pos()),
 									}
+									m.ReturnType = joinParameterTypes(m.Returns)
 
 									i.Methods = append(i.Methods, m)
 								}
@@ -355,21 +1039,54 @@ func (a *Architecture) parsePackageDir(fullPath, pkgPath string) error {
 
 							p.Interfaces[i.Name] = i
 						}
+
+						// Process other named types and type aliases
+						if !isStruct && !isInterface {
+							t := &NamedType{
+								Name:       typeSpec.Name.Name,
+								Underlying: exprToTypeString(typeSpec.Type),
+								Alias:      typeSpec.Assign.IsValid(),
+								Pkg:        p,
+								Position:   fset.Position(typeSpec.Pos()),
+							}
+							if t.Alias {
+								p.TypeAliases[t.Name] = t
+							} else {
+								p.NamedTypes[t.Name] = t
+							}
+						}
 					}
 				}
 			}
 		}
 
-		// Find methods for structs
-		for _, file := range pkg.Files {
+		// Find package-level functions and methods for structs
+		for _, file := range files {
+			p.Calls = append(p.Calls, collectCalls(file, fset)...)
+
 			for _, decl := range file.Decls {
 				funcDecl, ok := decl.(*ast.FuncDecl)
-				if !ok || funcDecl.Recv == nil {
+				if !ok {
+					continue
+				}
+
+				if funcDecl.Recv == nil {
+					f := &Function{
+						Name:     funcDecl.Name.Name,
+						Params:   parseFieldList(funcDecl.Type.Params),
+						Returns:  parseFieldList(funcDecl.Type.Results),
+						Pkg:      p,
+						Position: fset.Position(funcDecl.Name.Pos()),
+					}
+					f.ReturnType = joinParameterTypes(f.Returns)
+
+					p.Functions = append(p.Functions, f)
 					continue
 				}
 
 				// This is a method with a receiver
 				recvType := ""
+				pointerReceiver := false
 				if len(funcDecl.Recv.List) > 0 {
 					switch rt := funcDecl.Recv.List[0].Type.(type) {
 					case *ast.Ident:
@@ -377,63 +1094,22 @@ func (a *Architecture) parsePackageDir(fullPath, pkgPath string) error {
 					case *ast.StarExpr:
 						if ident, ok := rt.X.(*ast.Ident); ok {
 							recvType = ident.Name
+							pointerReceiver = true
 						}
 					}
 				}
 
 				if recvType != "" {
 					if s, found := p.Structs[recvType]; found {
+						// Process method parameters and return types
 						m := &Method{
-							Name:       funcDecl.Name.Name,
-							Params:     make([]*Parameter, 0),
-							ReturnType: "",
-						}
-
-						// Process method parameters
-						if funcDecl.Type.Params != nil {
-							for _, param := range funcDecl.Type.Params.List {
-								paramType := ""
-								switch t := param.Type.(type) {
-								case *ast.Ident:
-									paramType = t.Name
-								case *ast.SelectorExpr:
-									if x, ok := t.X.(*ast.Ident); ok {
-										paramType = x.Name + "." + t.Sel.Name
-									}
-								case *ast.StarExpr:
-									// Handle pointer types
-									switch pt := t.X.(type) {
-									case *ast.Ident:
-										paramType = "*" + pt.Name
-									case *ast.SelectorExpr:
-										if x, ok := pt.X.(*ast.Ident); ok {
-											paramType = "*" + x.Name + "." + pt.Sel.Name
-										}
-									}
-								}
-
-								// Handle multiple names for the same type
-								if len(param.Names) == 0 {
-									m.Params = append(m.Params, &Parameter{
-										Name: "",
-										Type: paramType,
-									})
-								} else {
-									for _, name := range param.Names {
-										m.Params = append(m.Params, &Parameter{
-											Name: name.Name,
-											Type: paramType,
-										})
-									}
-								}
-							}
-						}
-
-						// Process return types
-						if funcDecl.Type.Results != nil && funcDecl.Type.Results.List != nil {
-							// For simplicity, just note if there's a return value
-							m.ReturnType = "has_return"
+							Name:            funcDecl.Name.Name,
+							Params:          parseFieldList(funcDecl.Type.Params),
+							Returns:         parseFieldList(funcDecl.Type.Results),
+							PointerReceiver: pointerReceiver,
+							Position:        fset.Position(funcDecl.Name.Pos()),
 						}
+						m.ReturnType = joinParameterTypes(m.Returns)
 
 						s.Methods = append(s.Methods, m)
 					}
@@ -441,13 +1117,172 @@ func (a *Architecture) parsePackageDir(fullPath, pkgPath string) error {
 			}
 		}
 
-		a.Packages[pkgPath] = p
+		parsed = append(parsed, p)
 	}
 
+	a.storePackages(parsed)
+	a.saveCache(pkgPath, cacheKey, parsed)
 	return nil
 }
 
+// storePackages adds parsed packages to the architecture under their paths
+func (a *Architecture) storePackages(pkgs []*Package) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for _, p := range pkgs {
+		a.Packages[p.Path] = p
+	}
+}
+
+// isExcluded checks if a package path matches any of the exclude patterns
+func (a *Architecture) isExcluded(pkgPath string) bool {
+	for _, pattern := range a.exclude {
+		if pattern.MatchString(filepath.ToSlash(pkgPath)) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseFieldList converts a parameter or result list into parameters, one per value
+func parseFieldList(fields *ast.FieldList) []*Parameter {
+	params := make([]*Parameter, 0)
+	if fields == nil {
+		return params
+	}
+
+	for _, field := range fields.List {
+		paramType := exprToTypeString(field.Type)
+
+		// Handle multiple names for the same type
+		if len(field.Names) == 0 {
+			params = append(params, &Parameter{
+				Name: "",
+				Type: paramType,
+			})
+		} else {
+			for _, name := range field.Names {
+				params = append(params, &Parameter{
+					Name: name.Name,
+					Type: paramType,
+				})
+			}
+		}
+	}
+
+	return params
+}
+
+// joinParameterTypes renders the types of the given parameters as a comma-separated list
+func joinParameterTypes(params []*Parameter) string {
+	types := make([]string, 0, len(params))
+	for _, p := range params {
+		types = append(types, p.Type)
+	}
+	return strings.Join(types, ", ")
+}
+
+// exprToTypeString renders a type expression as it would appear in Go source
+func exprToTypeString(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		return t.Name
+	case *ast.SelectorExpr:
+		if x, ok := t.X.(*ast.Ident); ok {
+			return x.Name + "." + t.Sel.Name
+		}
+	case *ast.StarExpr:
+		// Handle pointer types
+		return "*" + exprToTypeString(t.X)
+	case *ast.ArrayType:
+		// Handle slices and fixed-size arrays
+		if t.Len == nil {
+			return "[]" + exprToTypeString(t.Elt)
+		}
+		if lit, ok := t.Len.(*ast.BasicLit); ok {
+			return "[" + lit.Value + "]" + exprToTypeString(t.Elt)
+		}
+		return "[...]" + exprToTypeString(t.Elt)
+	case *ast.Ellipsis:
+		// Handle variadic parameters
+		return "..." + exprToTypeString(t.Elt)
+	case *ast.MapType:
+		return "map[" + exprToTypeString(t.Key) + "]" + exprToTypeString(t.Value)
+	case *ast.ChanType:
+		switch t.Dir {
+		case ast.SEND:
+			return "chan<- " + exprToTypeString(t.Value)
+		case ast.RECV:
+			return "<-chan " + exprToTypeString(t.Value)
+		default:
+			return "chan " + exprToTypeString(t.Value)
+		}
+	case *ast.FuncType:
+		return "func" + funcSignatureString(t)
+	case *ast.InterfaceType:
+		if t.Methods == nil || len(t.Methods.List) == 0 {
+			return "interface{}"
+		}
+		return interfaceTypeString(t)
+	case *ast.StructType:
+		if t.Fields == nil || len(t.Fields.List) == 0 {
+			return "struct{}"
+		}
+		return "struct{...}"
+	case *ast.ParenExpr:
+		return exprToTypeString(t.X)
+	}
+	return ""
+}
+
+// interfaceTypeString renders an anonymous interface type with its methods and embedded
+// types, e.g. "interface{ Log(string); io.Closer }". Interfaces with elements that can't
+// be rendered, such as type constraint unions, are rendered as "interface{...}".
+func interfaceTypeString(t *ast.InterfaceType) string {
+	elements := []string{}
+	for _, field := range t.Methods.List {
+		if funcType, ok := field.Type.(*ast.FuncType); ok && len(field.Names) > 0 {
+			for _, name := range field.Names {
+				elements = append(elements, name.Name+funcSignatureString(funcType))
+			}
+			continue
+		}
+
+		embedded := exprToTypeString(field.Type)
+		if embedded == "" {
+			return "interface{...}"
+		}
+		elements = append(elements, embedded)
+	}
+	return "interface{ " + strings.Join(elements, "; ") + " }"
+}
+
+// funcSignatureString renders the parameter and result types of a function type,
+// e.g. "(context.Context, string) (*User, error)"
+func funcSignatureString(ft *ast.FuncType) string {
+	signature := "(" + joinParameterTypes(parseFieldList(ft.Params)) + ")"
+
+	results := parseFieldList(ft.Results)
+	switch {
+	case len(results) == 1 && results[0].Name == "":
+		signature += " " + results[0].Type
+	case len(results) > 0:
+		signature += " (" + joinParameterTypes(results) + ")"
+	}
+
+	return signature
+}
+
 // GetPackage returns a package by path
 func (a *Architecture) GetPackage(pkgPath string) *Package {
 	return a.Packages[pkgPath]
 }
+
+// importPosition returns the position of the import at the given index of Imports,
+// or an empty position if it is not known
+func (p *Package) importPosition(idx int) token.Position {
+	if idx < len(p.ImportSpecs) && p.ImportSpecs[idx].Path == p.Imports[idx] {
+		return p.ImportSpecs[idx].Position
+	}
+	return token.Position{}
+}
@@ -0,0 +1,159 @@
+package arctest
+
+import "fmt"
+
+// TestingT is the subset of testing.TB used by assertions, so that *testing.T and
+// *testing.B can be passed directly
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+	Logf(format string, args ...interface{})
+}
+
+// Assertion runs architecture checks against a layered architecture and reports
+// violations to a test. Assertions can be chained.
+type Assertion struct {
+	t       TestingT
+	layered *LayeredArchitecture
+}
+
+// LayerAssertion runs checks scoped to a single layer
+type LayerAssertion struct {
+	*Assertion
+	layer *Layer
+}
+
+// Assert creates an assertion that reports violations of the layered architecture
+// through t.Errorf, e.g. arctest.Assert(t, layeredArch).Layer("Domain").DoesNotDependOn("Application")
+func Assert(t TestingT, la *LayeredArchitecture) *Assertion {
+	return &Assertion{t: t, layered: la}
+}
+
+// report fails the test for every error-level violation and logs warnings. Violations
+// suppressed by //arctest:ignore comments are only counted.
+func (as *Assertion) report(violations []Violation) {
+	as.t.Helper()
+	if as.layered.arch != nil {
+		var suppressed int
+		violations, suppressed = as.layered.arch.FilterIgnored(violations)
+		if suppressed > 0 {
+			as.t.Logf("%d architecture violation(s) suppressed by arctest:ignore comments", suppressed)
+		}
+	}
+	for _, v := range violations {
+		if v.IsWarning() {
+			as.t.Logf("%s", v)
+		} else {
+			as.t.Errorf("%s", v)
+		}
+	}
+}
+
+// fail reports an assertion that couldn't be run
+func (as *Assertion) fail(format string, args ...interface{}) {
+	as.t.Helper()
+	as.t.Errorf("%s", fmt.Sprintf(format, args...))
+}
+
+// HasNoViolations asserts that the layered architecture has no violations of its rules
+func (as *Assertion) HasNoViolations() *Assertion {
+	as.t.Helper()
+	violations, err := as.layered.CheckDetailed()
+	if err != nil {
+		as.fail("Failed to check layered architecture: %v", err)
+		return as
+	}
+	as.report(violations)
+	return as
+}
+
+// HasNoCycles asserts that no packages of the architecture import each other in a cycle
+func (as *Assertion) HasNoCycles() *Assertion {
+	as.t.Helper()
+	if as.layered.arch == nil {
+		as.fail("layered architecture is not associated with an architecture")
+		return as
+	}
+	_, violations := as.layered.arch.HasNoCycles()
+	for _, violation := range violations {
+		as.t.Errorf("%s", violation)
+	}
+	return as
+}
+
+// Layer scopes the following assertions to the layer with the given name
+func (as *Assertion) Layer(name string) *LayerAssertion {
+	as.t.Helper()
+	layer := as.layered.WhereLayer(name)
+	if layer == nil {
+		as.fail("Layer %q not found", name)
+	}
+	return &LayerAssertion{Assertion: as, layer: layer}
+}
+
+// DoesNotDependOn asserts that the layer doesn't import the layer with the given name
+func (lt *LayerAssertion) DoesNotDependOn(targetLayerName string) *LayerAssertion {
+	lt.t.Helper()
+	if lt.layer == nil {
+		return lt
+	}
+
+	targetLayer := lt.layered.WhereLayer(targetLayerName)
+	if targetLayer == nil {
+		lt.fail("Layer %q not found", targetLayerName)
+		return lt
+	}
+
+	rule, err := lt.layer.DoesNotDependOnLayer(targetLayer)
+	if err != nil {
+		lt.fail("Failed to create layer dependency rule: %v", err)
+		return lt
+	}
+
+	_, violations := lt.layered.arch.ValidateDependenciesWithRulesDetailed([]*DependencyRule{rule})
+	lt.report(violations)
+	return lt
+}
+
+// MayOnlyImportExternal asserts that the layer only imports the standard library, its own
+// module and external packages below one of the allowed paths
+func (lt *LayerAssertion) MayOnlyImportExternal(allowed ...string) *LayerAssertion {
+	lt.t.Helper()
+	if lt.layer == nil || lt.layered.arch == nil {
+		return lt
+	}
+
+	_, violations := lt.layered.arch.ValidateExternalImportsDetailed([]*ExternalImportRule{lt.layer.MayOnlyImportExternal(allowed)})
+	lt.report(violations)
+	return lt
+}
+
+// StructNamesMatch asserts that the names of all structs in the layer match a pattern
+func (lt *LayerAssertion) StructNamesMatch(namePattern string) *LayerAssertion {
+	lt.t.Helper()
+	return lt.checkNaming(lt.layer.EnforceStructNaming, namePattern)
+}
+
+// InterfaceNamesMatch asserts that the names of all interfaces in the layer match a pattern
+func (lt *LayerAssertion) InterfaceNamesMatch(namePattern string) *LayerAssertion {
+	lt.t.Helper()
+	return lt.checkNaming(lt.layer.EnforceInterfaceNaming, namePattern)
+}
+
+// checkNaming validates a naming rule created by newRule
+func (lt *LayerAssertion) checkNaming(newRule func(string) (*NamingRule, error), namePattern string) *LayerAssertion {
+	lt.t.Helper()
+	if lt.layer == nil || lt.layered.arch == nil {
+		return lt
+	}
+
+	rule, err := newRule(namePattern)
+	if err != nil {
+		lt.fail("Failed to create naming rule: %v", err)
+		return lt
+	}
+
+	_, violations := lt.layered.arch.ValidateNamingRulesDetailed([]*NamingRule{rule})
+	lt.report(violations)
+	return lt
+}
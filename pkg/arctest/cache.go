@@ -0,0 +1,189 @@
+package arctest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+)
+
+// cacheVersion is part of every cache key, so that entries written by an older
+// version of the parser are never loaded. Changes to the fields of the cached model are
+// covered by modelFingerprint; bump the version when the parser fills fields differently.
+const cacheVersion = "6"
+
+// modelFingerprint describes the serialized fields of Package and every type it refers
+// to. It is part of every cache key, so that entries written before a field was added,
+// such as Import.Kind, are never loaded with the field left empty.
+var modelFingerprint = typeFingerprint(reflect.TypeOf(Package{}), map[reflect.Type]bool{})
+
+// typeFingerprint renders a type with the names and types of the exported fields of
+// every struct type it contains, visiting each struct type once
+func typeFingerprint(t reflect.Type, visited map[reflect.Type]bool) string {
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Array:
+		return t.Kind().String() + "(" + typeFingerprint(t.Elem(), visited) + ")"
+	case reflect.Map:
+		return "map(" + typeFingerprint(t.Key(), visited) + "," + typeFingerprint(t.Elem(), visited) + ")"
+	case reflect.Struct:
+		if visited[t] {
+			return t.Name()
+		}
+		visited[t] = true
+
+		var b strings.Builder
+		b.WriteString(t.Name() + "{")
+		for idx := 0; idx < t.NumField(); idx++ {
+			field := t.Field(idx)
+			if !field.IsExported() || field.Tag.Get("json") == "-" {
+				continue
+			}
+			fmt.Fprintf(&b, "%s:%s;", field.Name, typeFingerprint(field.Type, visited))
+		}
+		b.WriteString("}")
+		return b.String()
+	default:
+		return t.String()
+	}
+}
+
+// cacheEntry is the on-disk representation of the packages parsed from a directory
+type cacheEntry struct {
+	Key      string
+	Packages []*Package
+}
+
+// WithCache stores parse results in the given directory and loads packages from it as long
+// as the name, size and modification time of every source file in their directory are
+// unchanged. Caching only applies to architectures created with New, since file systems
+// passed to NewFromFS may not report reliable modification times.
+func WithCache(dir string) Option {
+	return func(a *Architecture) {
+		a.cacheDir = dir
+	}
+}
+
+// cacheKey derives the cache key of a package directory from its source files.
+// An empty key is returned if caching is disabled or the files can't be inspected.
+func (a *Architecture) cacheKey(entries []fs.DirEntry) string {
+	if a.cacheDir == "" || a.basePath == "" {
+		return ""
+	}
+
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\x00%s\x00%t\x00", cacheVersion, modelFingerprint, a.IncludeTests)
+	fmt.Fprintf(h, "%s\x00%s\x00%s\x00", a.BuildContext.GOOS, a.BuildContext.GOARCH, strings.Join(a.BuildContext.BuildTags, ","))
+	for _, entry := range entries {
+		if entry.IsDir() || !a.isSourceFile(entry.Name()) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return ""
+		}
+		fmt.Fprintf(h, "%s\x00%d\x00%d\x00", entry.Name(), info.Size(), info.ModTime().UnixNano())
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// cacheFile returns the file the packages of a directory are cached in
+func (a *Architecture) cacheFile(pkgPath string) string {
+	sum := sha256.Sum256([]byte(filepath.Join(a.basePath, pkgPath)))
+	return filepath.Join(a.cacheDir, hex.EncodeToString(sum[:])+".json")
+}
+
+// loadCache loads the packages of a directory if they were cached with the given key
+func (a *Architecture) loadCache(pkgPath, key string) ([]*Package, bool) {
+	if key == "" {
+		return nil, false
+	}
+
+	data, err := os.ReadFile(a.cacheFile(pkgPath))
+	if err != nil {
+		return nil, false
+	}
+
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil || entry.Key != key {
+		return nil, false
+	}
+
+	for _, p := range entry.Packages {
+		a.adoptPackage(p)
+	}
+
+	return entry.Packages, true
+}
+
+// adoptPackage restores the references of a deserialized package that aren't part
+// of its serialized form
+func (a *Architecture) adoptPackage(p *Package) {
+	p.Fset = token.NewFileSet()
+	p.arch = a
+	for _, s := range p.Structs {
+		s.Pkg = p
+	}
+	for _, i := range p.Interfaces {
+		i.Pkg = p
+	}
+	for _, t := range p.NamedTypes {
+		t.Pkg = p
+	}
+	for _, t := range p.TypeAliases {
+		t.Pkg = p
+	}
+	for _, f := range p.Functions {
+		f.Pkg = p
+	}
+	for _, v := range p.Variables {
+		v.Pkg = p
+	}
+	for _, c := range p.Constants {
+		c.Pkg = p
+	}
+}
+
+// saveCache stores the packages parsed from a directory. Failures are only logged,
+// since the cache is an optimization.
+func (a *Architecture) saveCache(pkgPath, key string, pkgs []*Package) {
+	if key == "" {
+		return
+	}
+
+	if err := a.writeCache(pkgPath, cacheEntry{Key: key, Packages: pkgs}); err != nil {
+		a.logf("Failed to cache package %s: %v", pkgPath, err)
+	}
+}
+
+// writeCache writes a cache entry through a temporary file, so that concurrent
+// readers never see a partially written entry
+func (a *Architecture) writeCache(pkgPath string, entry cacheEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(a.cacheDir, 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(a.cacheDir, "package-*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), a.cacheFile(pkgPath))
+}
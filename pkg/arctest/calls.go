@@ -0,0 +1,185 @@
+package arctest
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Call represents a function or method call
+type Call struct {
+	// Name is the called function as written, e.g. "panic" or "s.repo.Save". Functions of
+	// imported packages are named after the import path regardless of the import name,
+	// e.g. "os.Exit" or "github.com/pkg/errors.Wrap".
+	Name     string
+	Caller   string // enclosing function, e.g. "NewServer" or "Server.Start", empty for package-level variables
+	Position token.Position
+}
+
+// collectCalls finds the calls in the function bodies and package-level variable
+// declarations of a file, including calls inside closures
+func collectCalls(file *ast.File, fset *token.FileSet) []*Call {
+	imports := make(map[string]string)
+	for _, imp := range file.Imports {
+		importPath, err := strconv.Unquote(imp.Path.Value)
+		if err != nil {
+			continue
+		}
+		name := importPath[strings.LastIndex(importPath, "/")+1:]
+		if imp.Name != nil {
+			name = imp.Name.Name
+		}
+		imports[name] = importPath
+	}
+
+	calls := []*Call{}
+	for _, decl := range file.Decls {
+		caller := ""
+		var node ast.Node = decl
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			if d.Body == nil {
+				continue
+			}
+			caller = d.Name.Name
+			if d.Recv != nil && len(d.Recv.List) > 0 {
+				if recv := receiverTypeName(d.Recv.List[0].Type); recv != "" {
+					caller = recv + "." + caller
+				}
+			}
+			node = d.Body
+		case *ast.GenDecl:
+			if d.Tok != token.VAR {
+				continue
+			}
+		}
+
+		ast.Inspect(node, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			if name := callName(call.Fun, imports); name != "" {
+				calls = append(calls, &Call{
+					Name:     name,
+					Caller:   caller,
+					Position: fset.Position(call.Pos()),
+				})
+			}
+			return true
+		})
+	}
+
+	return calls
+}
+
+// receiverTypeName returns the name of the type of a method receiver, e.g. "Server" for *Server
+func receiverTypeName(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		return t.Name
+	case *ast.StarExpr:
+		return receiverTypeName(t.X)
+	case *ast.IndexExpr:
+		return receiverTypeName(t.X)
+	case *ast.IndexListExpr:
+		return receiverTypeName(t.X)
+	}
+	return ""
+}
+
+// callName renders the function expression of a call. An empty string is returned for
+// calls that aren't made through a name, such as immediately invoked closures.
+func callName(fun ast.Expr, imports map[string]string) string {
+	switch f := fun.(type) {
+	case *ast.Ident:
+		return f.Name
+	case *ast.ParenExpr:
+		return callName(f.X, imports)
+	case *ast.IndexExpr:
+		// Explicitly instantiated generic function
+		return callName(f.X, imports)
+	case *ast.IndexListExpr:
+		return callName(f.X, imports)
+	case *ast.SelectorExpr:
+		if x, ok := f.X.(*ast.Ident); ok && x.Obj == nil {
+			if importPath, ok := imports[x.Name]; ok {
+				return importPath + "." + f.Sel.Name
+			}
+		}
+		if x := selectorName(f.X); x != "" {
+			return x + "." + f.Sel.Name
+		}
+	}
+	return ""
+}
+
+// selectorName renders a chain of identifiers and selectors such as s.repo
+func selectorName(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		return e.Name
+	case *ast.SelectorExpr:
+		if x := selectorName(e.X); x != "" {
+			return x + "." + e.Sel.Name
+		}
+	}
+	return ""
+}
+
+// MustNotCall reports calls in the packages of this layer whose name matches the pattern,
+// e.g. `^(panic|os\.Exit|log\.Fatal.*)$`. See Call for how calls are named.
+func (l *Layer) MustNotCall(pattern string) ([]string, error) {
+	violations, err := l.MustNotCallDetailed(pattern)
+	if err != nil {
+		return nil, err
+	}
+	return violationStrings(violations), nil
+}
+
+// MustNotCallDetailed reports calls matching the pattern in the packages of this layer
+// and returns structured violations
+func (l *Layer) MustNotCallDetailed(pattern string) ([]Violation, error) {
+	if l.arch == nil {
+		return nil, fmt.Errorf("layer %q is not associated with an architecture", l.Name)
+	}
+
+	callRegex, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid call pattern: %w", err)
+	}
+
+	pkgPaths := make([]string, 0, len(l.arch.Packages))
+	for pkgPath := range l.arch.Packages {
+		pkgPaths = append(pkgPaths, pkgPath)
+	}
+	sort.Strings(pkgPaths)
+
+	violations := []Violation{}
+	for _, pkgPath := range pkgPaths {
+		if !l.Contains(pkgPath) {
+			continue
+		}
+
+		for _, call := range l.arch.Packages[pkgPath].Calls {
+			if !callRegex.MatchString(call.Name) {
+				continue
+			}
+
+			caller := "package-level variables"
+			if call.Caller != "" {
+				caller = fmt.Sprintf("%q", call.Caller)
+			}
+			violations = append(violations, newViolation(RuleTypeCall, call.Position, pkgPath, "",
+				"Package %q in layer %q calls %s in %s, which is forbidden by pattern %q",
+				pkgPath, l.Name, call.Name, caller, pattern,
+			))
+		}
+	}
+
+	return violations, nil
+}
@@ -0,0 +1,121 @@
+package arctest
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+)
+
+// ConstructorRule represents a rule that constructors must return structs rather than
+// interfaces. Only the "return structs" half of the "accept interfaces, return structs"
+// idiom is checked; use a ParameterRule with IncludeFreeFunctions for the parameters.
+type ConstructorRule struct {
+	PackagePattern  string   // regex pattern for the package paths of constructors
+	FunctionPattern string   // regex pattern for constructor names, e.g. "^New"
+	Severity        Severity // severity of violations, SeverityError if empty
+	pkgRegex        *regexp.Regexp
+	funcRegex       *regexp.Regexp
+}
+
+// NewConstructorRule creates a rule that functions matching funcPattern in packages
+// matching pkgPattern must not return an interface type
+func NewConstructorRule(pkgPattern, funcPattern string) (*ConstructorRule, error) {
+	pkgRegex, err := regexp.Compile(pkgPattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid package pattern: %w", err)
+	}
+
+	funcRegex, err := regexp.Compile(funcPattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid function pattern: %w", err)
+	}
+
+	return &ConstructorRule{
+		PackagePattern:  pkgPattern,
+		FunctionPattern: funcPattern,
+		pkgRegex:        pkgRegex,
+		funcRegex:       funcRegex,
+	}, nil
+}
+
+// ConstructorsReturnStructs checks the "accept interfaces, return structs" idiom: functions
+// matching funcPattern (e.g. "^New") in packages matching pkgPattern must not return an
+// interface type. Returned error values are allowed. Parameters are not checked.
+func (a *Architecture) ConstructorsReturnStructs(pkgPattern, funcPattern string) ([]string, error) {
+	violations, err := a.ConstructorsReturnStructsDetailed(pkgPattern, funcPattern)
+	if err != nil {
+		return nil, err
+	}
+	return violationStrings(violations), nil
+}
+
+// ConstructorsReturnStructsDetailed checks the "accept interfaces, return structs" idiom
+// and returns structured violations
+func (a *Architecture) ConstructorsReturnStructsDetailed(pkgPattern, funcPattern string) ([]Violation, error) {
+	rule, err := NewConstructorRule(pkgPattern, funcPattern)
+	if err != nil {
+		return nil, err
+	}
+	return a.checkConstructors([]*ConstructorRule{rule}), nil
+}
+
+// ValidateConstructors validates constructor rules.
+// Rules with SeverityWarning are reported but don't make the validation fail.
+func (a *Architecture) ValidateConstructors(rules []*ConstructorRule) (bool, []string) {
+	valid, violations := a.ValidateConstructorsDetailed(rules)
+	return valid, violationStrings(violations)
+}
+
+// ValidateConstructorsDetailed validates constructor rules and returns structured violations
+func (a *Architecture) ValidateConstructorsDetailed(rules []*ConstructorRule) (bool, []Violation) {
+	violations := a.checkConstructors(rules)
+	return !HasErrors(violations), violations
+}
+
+// checkConstructors flags matching functions that return an interface type. Return types
+// are classified like parameter types, so import aliases, qualified names and aliases of
+// interfaces are resolved, and any and interface{} count as interfaces.
+func (a *Architecture) checkConstructors(rules []*ConstructorRule) []Violation {
+	violations := []Violation{}
+	interfaces, structs := a.typeKinds()
+
+	pkgPaths := make([]string, 0, len(a.Packages))
+	for pkgPath := range a.Packages {
+		pkgPaths = append(pkgPaths, pkgPath)
+	}
+	sort.Strings(pkgPaths)
+
+	for _, rule := range rules {
+		for _, pkgPath := range pkgPaths {
+			if !rule.pkgRegex.MatchString(pkgPath) {
+				continue
+			}
+
+			pkg := a.Packages[pkgPath]
+			for _, f := range pkg.Functions {
+				if !rule.funcRegex.MatchString(f.Name) {
+					continue
+				}
+
+				for _, r := range f.Returns {
+					// Returning an error next to the constructed value is idiomatic, even
+					// though error is an interface
+					if r.Type == "error" || isPrimitiveType(r.Type) {
+						continue
+					}
+
+					if isInterface, _ := a.typeKind(pkg, r.Type, interfaces, structs); !isInterface {
+						continue
+					}
+
+					violations = append(violations, newViolation(RuleTypeConstructor, f.Position, pkgPath, "",
+						"Function %q in package %q returns interface type %q, but constructors should return structs",
+						f.Name, pkgPath, r.Type,
+					).withSeverity(rule.Severity))
+				}
+			}
+		}
+	}
+
+	return violations
+}
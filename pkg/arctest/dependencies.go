@@ -2,19 +2,43 @@ package arctest
 
 import (
 	"fmt"
+	"go/token"
+	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 )
 
 // DependencyRule represents a dependency rule
 type DependencyRule struct {
-	SourcePattern      string // regex pattern for source package
-	TargetPattern      string // regex pattern for target package
-	AllowedImports     bool   // if true, source can import target, if false, source cannot import target
+	SourcePattern      string    // regex pattern for source package
+	TargetPattern      string    // regex pattern for target package
+	AllowedImports     bool      // if true, source can import target, if false, source cannot import target
+	Exclusive          bool      // if true, source can only import target and the standard library
+	CaptureGroup       string    // if set, the rule only applies if this named group captures different values in source and target
+	SameCapture        bool      // if true, the rule applies if CaptureGroup captures the same value instead
+	Severity           Severity  // severity of violations, SeverityError if empty
+	MatchBy            MatchMode // what the target pattern is matched against, MatchByPath if empty
 	sourcePatternRegex *regexp.Regexp
 	targetPatternRegex *regexp.Regexp
+	sourceLayer        *Layer // if set, source packages are matched by membership of this layer
+	targetLayer        *Layer // if set, imports are matched by membership of this layer
+	matchStdlib        bool   // if true, the rule also applies to standard library imports
 }
 
+// MatchMode determines what the target pattern of a dependency rule is matched against
+type MatchMode string
+
+const (
+	// MatchByPath matches the target pattern against the import path and the path of the
+	// package it resolves to
+	MatchByPath MatchMode = "path"
+	// MatchByName matches the target pattern against the name of the imported package, which
+	// is its declared name if it was parsed and its last path element otherwise, ignoring
+	// major version suffixes such as /v2. This finds a package under any path prefix.
+	MatchByName MatchMode = "name"
+)
+
 // NewDependencyRule creates a new dependency rule
 func NewDependencyRule(sourcePattern, targetPattern string, allowedImports bool) (*DependencyRule, error) {
 	sourceRegex, err := regexp.Compile(sourcePattern)
@@ -36,36 +60,150 @@ func NewDependencyRule(sourcePattern, targetPattern string, allowedImports bool)
 	}, nil
 }
 
+// newLayerDependencyRule creates a dependency rule between two layers. The rule matches
+// packages by layer membership rather than by its patterns, which only describe it.
+func newLayerDependencyRule(sourceLayer, targetLayer *Layer, allowedImports bool) (*DependencyRule, error) {
+	rule, err := NewDependencyRule(sourceLayer.pattern(), targetLayer.pattern(), allowedImports)
+	if err != nil {
+		return nil, err
+	}
+	rule.sourceLayer = sourceLayer
+	rule.targetLayer = targetLayer
+	return rule, nil
+}
+
+// matchesSource checks if a package is subject to the rule
+func (r *DependencyRule) matchesSource(pkgPath string) bool {
+	if r.sourceLayer != nil {
+		return r.sourceLayer.Contains(pkgPath)
+	}
+	return r.sourcePatternRegex.MatchString(pkgPath)
+}
+
+// matchesTarget checks if an import is covered by the rule
+func (r *DependencyRule) matchesTarget(importPath string) bool {
+	if r.targetLayer != nil {
+		return r.targetLayer.containsImport(importPath)
+	}
+	return r.targetPatternRegex.MatchString(importPath)
+}
+
+// dependencyRuleMatches counts the packages a dependency rule applies to
+func (a *Architecture) dependencyRuleMatches(rule *DependencyRule) int {
+	count := 0
+	for pkgPath := range a.Packages {
+		if rule.matchesSource(pkgPath) {
+			count++
+		}
+	}
+	return count
+}
+
 // CheckDependencies checks all packages against the provided dependency rules
 func (a *Architecture) CheckDependencies(rules []*DependencyRule) ([]string, error) {
-	violations := []string{}
+	return violationStrings(a.checkDependencies(rules, nil)), nil
+}
+
+// checkDependencies checks all packages against the provided dependency rules
+// If sources is not nil, only imports of the packages it contains are checked.
+func (a *Architecture) checkDependencies(rules []*DependencyRule, sources map[string]bool) []Violation {
+	violations := []Violation{}
 
 	for pkgPath, pkg := range a.Packages {
-		for _, importPath := range pkg.Imports {
-			// Skip standard library imports that don't have dots or slashes
-			if !strings.Contains(importPath, ".") && !strings.Contains(importPath, "/") {
-				continue
+		if sources != nil && !sources[pkgPath] {
+			continue
+		}
+
+		for idx, importPath := range pkg.Imports {
+			forbidden, _ := a.evaluateImport(rules, pkgPath, importPath)
+			for _, f := range forbidden {
+				violations = append(violations, newViolation(RuleTypeDependency, pkg.importPosition(idx), pkgPath, importPath,
+					"Package %q imports %q, but this is not allowed by rule: %s",
+					pkgPath, importPath, f.detail,
+				).withSeverity(f.rule.Severity))
 			}
+		}
+	}
 
-			for _, rule := range rules {
-				// Check if this package matches the source pattern
-				if rule.sourcePatternRegex.MatchString(pkgPath) {
-					// Check if the import matches the target pattern
-					if rule.targetPatternRegex.MatchString(importPath) {
-						// If imports are not allowed, this is a violation
-						if !rule.AllowedImports {
-							violations = append(violations, fmt.Sprintf(
-								"Package %q imports %q, but this is not allowed by rule: %s cannot import %s",
-								pkgPath, importPath, rule.SourcePattern, rule.TargetPattern,
-							))
-						}
-					}
-				}
+	return violations
+}
+
+// majorVersionRegex matches the major version suffix of a module path, e.g. v2
+var majorVersionRegex = regexp.MustCompile(`^v[0-9]+$`)
+
+// importedPackageName returns the declared name of the package an import resolves to, or
+// the last element of the import path that isn't a major version suffix if it wasn't parsed
+func (a *Architecture) importedPackageName(importPath string) string {
+	if resolved, ok := a.ResolveImport(importPath); ok {
+		if pkg := a.Packages[resolved]; pkg != nil && pkg.Name != "" {
+			return pkg.Name
+		}
+	}
+
+	elements := strings.Split(importPath, "/")
+	name := elements[len(elements)-1]
+	if len(elements) > 1 && majorVersionRegex.MatchString(name) {
+		name = elements[len(elements)-2]
+	}
+	return name
+}
+
+// forbiddenImport is a rule forbidding an import together with a description of the rule
+type forbiddenImport struct {
+	rule   *DependencyRule
+	detail string // e.g. "^domain$ cannot import .*utils$"
+}
+
+// evaluateImport evaluates dependency rules against an import of a package and returns
+// the rules forbidding it and those allowing it. Both checkDependencies and the check of a
+// layered architecture use it, so that rules match the same way: targets are matched
+// against the import path as well as the path of the package it resolves to, and standard
+// library imports are skipped unless a rule names them explicitly (see MustNotImportModule),
+// so that e.g. a ".*http.*" target never matches net/http.
+func (a *Architecture) evaluateImport(rules []*DependencyRule, pkgPath, importPath string) ([]forbiddenImport, []*DependencyRule) {
+	var forbidden []forbiddenImport
+	var allowed []*DependencyRule
+
+	targets := []string{importPath}
+	if resolved, ok := a.ResolveImport(importPath); ok && resolved != importPath {
+		targets = append(targets, resolved)
+	}
+	matchesTarget := func(rule *DependencyRule) bool {
+		if rule.MatchBy == MatchByName && rule.targetLayer == nil {
+			return rule.targetPatternRegex.MatchString(a.importedPackageName(importPath))
+		}
+		for _, target := range targets {
+			if rule.matchesTarget(target) {
+				return true
+			}
+		}
+		return false
+	}
+
+	stdlib := IsStandardLibrary(importPath)
+	for _, rule := range rules {
+		switch {
+		case stdlib && !rule.matchStdlib:
+		case rule.CaptureGroup != "":
+			// Capture rules compare a named group between the source and the target
+			if detail, ok := a.checkCaptureRule(rule, pkgPath, importPath); ok {
+				forbidden = append(forbidden, forbiddenImport{rule: rule, detail: detail})
+			}
+		case !rule.matchesSource(pkgPath):
+		case rule.Exclusive:
+			// Exclusive rules forbid every import outside of the allowed targets
+			if !matchesTarget(rule) {
+				forbidden = append(forbidden, forbiddenImport{rule: rule, detail: fmt.Sprintf("%s may only import %s", rule.SourcePattern, rule.TargetPattern)})
 			}
+		case !matchesTarget(rule):
+		case rule.AllowedImports:
+			allowed = append(allowed, rule)
+		default:
+			forbidden = append(forbidden, forbiddenImport{rule: rule, detail: fmt.Sprintf("%s cannot import %s", rule.SourcePattern, rule.TargetPattern)})
 		}
 	}
 
-	return violations, nil
+	return forbidden, allowed
 }
 
 // Layer represents a layer in a layered architecture
@@ -73,11 +211,16 @@ type Layer struct {
 	Name        string
 	Packages    []string // Package paths or patterns
 	patterns    []*regexp.Regexp
+	excludes    []*regexp.Regexp     // packages matching any of these don't belong to the layer
+	subpackages bool                 // if true, descendants of matching packages belong to the layer
 	arch        *Architecture        // Reference to the architecture
 	layeredArch *LayeredArchitecture // Reference to the layered architecture
 }
 
-// NewLayer creates a new layer with the given name and package patterns
+// NewLayer creates a new layer with the given name and package patterns.
+// Simple patterns without "/", "|", "(" or "[" also match subpackages, so "^domain$"
+// covers "domain/user". Other patterns such as "^internal/domain$" are used as is,
+// unless IncludingSubpackages is called on the layer.
 func NewLayer(name string, packages ...string) (*Layer, error) {
 	patterns := make([]*regexp.Regexp, 0, len(packages))
 
@@ -117,9 +260,84 @@ func NewLayer(name string, packages ...string) (*Layer, error) {
 	}, nil
 }
 
-// Contains checks if a package belongs to this layer
+// Contains checks if a package belongs to this layer.
+// Once the layer is part of an architecture, full import paths of parsed packages
+// are resolved to their package paths before matching, and package paths are also
+// matched in their full import path form, so path-style patterns such as
+// "^.*/application/.*$" work for both.
 func (l *Layer) Contains(pkgPath string) bool {
-	for _, pattern := range l.patterns {
+	if l.matches(pkgPath) {
+		return true
+	}
+
+	if l.arch != nil {
+		if resolved, ok := l.arch.ResolveImport(pkgPath); ok && resolved != pkgPath {
+			return l.matches(resolved)
+		}
+		if importPath := l.arch.ImportPath(pkgPath); importPath != pkgPath {
+			return l.matches(importPath)
+		}
+	}
+	return false
+}
+
+// containsImport checks if an import path refers to a package of this layer, either
+// through the package it resolves to or through the import path itself
+func (l *Layer) containsImport(importPath string) bool {
+	if l.arch != nil {
+		if resolved, ok := l.arch.ResolveImport(importPath); ok && l.matches(resolved) {
+			return true
+		}
+	}
+	return l.matches(importPath) || l.matchesImport(importPath)
+}
+
+// pattern returns a single regex pattern describing the layer's packages
+func (l *Layer) pattern() string {
+	return strings.Join(l.Packages, "|")
+}
+
+// IncludingSubpackages makes every pattern of the layer match the descendants of the
+// packages it matches as well, so "^internal/domain$" covers "internal/domain/user"
+func (l *Layer) IncludingSubpackages() *Layer {
+	l.subpackages = true
+	return l
+}
+
+// candidatePaths returns the paths a package path is matched with: the path itself and,
+// if subpackages are included, every ancestor path
+func (l *Layer) candidatePaths(pkgPath string) []string {
+	candidates := []string{pkgPath}
+	if !l.subpackages {
+		return candidates
+	}
+
+	for {
+		idx := strings.LastIndexAny(pkgPath, "/"+string(filepath.Separator))
+		if idx <= 0 {
+			return candidates
+		}
+		pkgPath = pkgPath[:idx]
+		candidates = append(candidates, pkgPath)
+	}
+}
+
+// Excluding removes packages matching any of the given regex patterns from the layer,
+// e.g. generated code below a layer's packages
+func (l *Layer) Excluding(patterns ...string) (*Layer, error) {
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exclude pattern %q: %w", pattern, err)
+		}
+		l.excludes = append(l.excludes, re)
+	}
+	return l, nil
+}
+
+// isExcluded checks if a package path or import path is excluded from the layer
+func (l *Layer) isExcluded(pkgPath string) bool {
+	for _, pattern := range l.excludes {
 		if pattern.MatchString(pkgPath) {
 			return true
 		}
@@ -127,14 +345,84 @@ func (l *Layer) Contains(pkgPath string) bool {
 	return false
 }
 
+// matches checks if a package path matches any of the layer's patterns
+func (l *Layer) matches(pkgPath string) bool {
+	if l.isExcluded(pkgPath) {
+		return false
+	}
+	for _, candidate := range l.candidatePaths(pkgPath) {
+		for _, pattern := range l.patterns {
+			if pattern.MatchString(candidate) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// matchesImport checks if an import path that couldn't be resolved to a parsed package
+// belongs to this layer, trying every pattern of the layer
+func (l *Layer) matchesImport(importPath string) bool {
+	if l.isExcluded(importPath) {
+		return false
+	}
+	for _, candidate := range l.candidatePaths(importPath) {
+		for idx, pattern := range l.patterns {
+			// Improve matching to detect the layer based on the import path
+			// For packages like github.com/mstrYoda/go-arctest/examples/example_project/utils
+			// we want to match against the "utils" part
+			if pattern.MatchString(candidate) ||
+				strings.HasSuffix(candidate, "/"+strings.TrimPrefix(strings.TrimSuffix(l.Packages[idx], "$"), "^")) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // SetArchitecture sets the architecture reference for this layer
 // This is called internally when the layer is added to a layered architecture
 func (l *Layer) SetArchitecture(arch *Architecture) {
 	l.arch = arch
 }
 
+// PackagesMustSharePrefix checks that every parsed package belonging to this layer
+// has a module-relative path at or below the given prefix. The prefix is compared by
+// path elements, so "domain" and "domain/" both cover domain and domain/user but not domainx.
+func (l *Layer) PackagesMustSharePrefix(prefix string) (bool, []string) {
+	if l.arch == nil {
+		return false, []string{fmt.Sprintf("layer %q is not associated with an architecture", l.Name)}
+	}
+
+	prefix = strings.TrimSuffix(filepath.ToSlash(prefix), "/")
+
+	pkgPaths := make([]string, 0, len(l.arch.Packages))
+	for pkgPath := range l.arch.Packages {
+		if l.Contains(pkgPath) {
+			pkgPaths = append(pkgPaths, pkgPath)
+		}
+	}
+	sort.Strings(pkgPaths)
+
+	violations := []string{}
+	for _, pkgPath := range pkgPaths {
+		p := filepath.ToSlash(pkgPath)
+		if prefix != "" && p != prefix && !strings.HasPrefix(p, prefix+"/") {
+			violations = append(violations, fmt.Sprintf(
+				"Package %q in layer %q is not located under %q",
+				pkgPath, l.Name, prefix,
+			))
+		}
+	}
+
+	return len(violations) == 0, violations
+}
+
 // DependsOn creates a rule that this layer may depend on another layer
 func (l *Layer) DependsOn(targetLayerName string) error {
+	if l.layeredArch == nil {
+		return fmt.Errorf("layer %q is not part of a layered architecture", l.Name)
+	}
 	return l.layeredArch.AddRule(l.Name, targetLayerName)
 }
 
@@ -143,28 +431,46 @@ func (l *Layer) DependsOnLayer(targetLayer *Layer) error {
 	if targetLayer == nil {
 		return fmt.Errorf("target layer cannot be nil")
 	}
+	if l.layeredArch == nil {
+		return fmt.Errorf("layer %q is not part of a layered architecture", l.Name)
+	}
 	return l.layeredArch.AddRule(l.Name, targetLayer.Name)
 }
 
+// MustNotDependOnLayer forbids this layer to depend on another layer within its layered
+// architecture. It is the inverse of DependsOnLayer: the rule is checked by Check and takes
+// precedence over rules allowing the dependency.
+func (l *Layer) MustNotDependOnLayer(targetLayer *Layer) error {
+	if targetLayer == nil {
+		return fmt.Errorf("target layer cannot be nil")
+	}
+	if l.layeredArch == nil {
+		return fmt.Errorf("layer %q is not part of a layered architecture", l.Name)
+	}
+
+	rule, err := l.DoesNotDependOnLayer(targetLayer)
+	if err != nil {
+		return err
+	}
+	l.layeredArch.AddDependencyConstraint(rule)
+	l.layeredArch.ruleNames[rule] = fmt.Sprintf("layer %q must not depend on layer %q", l.Name, targetLayer.Name)
+
+	return nil
+}
+
 // DoesNotDependOn creates a rule that this layer should not depend on a specific package pattern
 func (l *Layer) DoesNotDependOn(targetPattern string) (*DependencyRule, error) {
 	if l.arch == nil {
 		return nil, fmt.Errorf("layer %q is not associated with an architecture", l.Name)
 	}
 
-	// Create patterns that will match any fully qualified import path
-	// ending with the specified package patterns
-	sourcePatterns := make([]string, 0, len(l.Packages))
-	for _, pkg := range l.Packages {
-		// Remove ^ and $ markers if present
-		cleanPattern := strings.TrimPrefix(pkg, "^")
-		cleanPattern = strings.TrimSuffix(cleanPattern, "$")
-		// Create pattern that matches any path ending with the package
-		sourcePatterns = append(sourcePatterns, fmt.Sprintf("(^|/)%s$", cleanPattern))
+	rule, err := NewDependencyRule(l.pattern(), targetPattern, false)
+	if err != nil {
+		return nil, err
 	}
-	sourcePattern := strings.Join(sourcePatterns, "|")
+	rule.sourceLayer = l
 
-	return NewDependencyRule(sourcePattern, targetPattern, false)
+	return rule, nil
 }
 
 // DoesNotDependOnLayer creates a rule that this layer should not depend on another layer
@@ -177,42 +483,8 @@ func (l *Layer) DoesNotDependOnLayer(targetLayer *Layer) (*DependencyRule, error
 		return nil, fmt.Errorf("target layer cannot be nil")
 	}
 
-	// Build a comprehensive source pattern
-	var sourcePatterns []string
-	for _, pkg := range l.Packages {
-		// Remove ^ and $ markers if present
-		cleanPattern := strings.TrimPrefix(pkg, "^")
-		cleanPattern = strings.TrimSuffix(cleanPattern, "$")
-
-		// Add patterns to match:
-		// 1. The package name exactly (for packages without a path)
-		// 2. The package at the end of a path (to catch example.com/mypackage)
-		// 3. The package with subpackages (to catch mypackage/subpackage)
-		sourcePatterns = append(sourcePatterns,
-			fmt.Sprintf("^%s$", cleanPattern),       // Exact match
-			fmt.Sprintf("(^|.*/)%s$", cleanPattern), // At end of path
-			fmt.Sprintf("^%s/.*$", cleanPattern))    // With subpackages
-	}
-	sourcePattern := strings.Join(sourcePatterns, "|")
-
-	// Build a comprehensive target pattern
-	var targetPatterns []string
-	for _, pkg := range targetLayer.Packages {
-		// Remove ^ and $ markers if present
-		cleanPattern := strings.TrimPrefix(pkg, "^")
-		cleanPattern = strings.TrimSuffix(cleanPattern, "$")
-
-		// Similar patterns for target
-		targetPatterns = append(targetPatterns,
-			fmt.Sprintf("^%s$", cleanPattern),       // Exact match
-			fmt.Sprintf("(^|.*/)%s$", cleanPattern), // At end of path
-			fmt.Sprintf("^%s/.*$", cleanPattern),    // With subpackages
-			fmt.Sprintf(".*/%s$", cleanPattern))     // Just the package name at the end of any path
-	}
-	targetPattern := strings.Join(targetPatterns, "|")
-
 	// Create a rule that disallows dependencies from source to target
-	rule, err := NewDependencyRule(sourcePattern, targetPattern, false)
+	rule, err := newLayerDependencyRule(l, targetLayer, false)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create dependency rule: %w", err)
 	}
@@ -227,67 +499,73 @@ func (l *Layer) StructsImplementInterfaces(structPattern, interfacePattern strin
 		return nil, fmt.Errorf("layer %q is not associated with an architecture", l.Name)
 	}
 
-	// Modify the struct pattern to only match structs in this layer's packages
-	layerScopedStructPattern := l.getScopedPattern(structPattern)
-	return NewInterfaceImplementationRule(layerScopedStructPattern, interfacePattern)
+	rule, err := NewInterfaceImplementationRule(structPattern, interfacePattern)
+	if err != nil {
+		return nil, err
+	}
+	rule.Layer = l
+	return rule, nil
 }
 
 // MethodsShouldUseInterfaceParameters creates a rule that methods in this layer should use interface parameters
 func (l *Layer) MethodsShouldUseInterfaceParameters(structPattern, methodPattern, parameterTypePattern string) (*ParameterRule, error) {
-	if l.arch == nil {
-		return nil, fmt.Errorf("layer %q is not associated with an architecture", l.Name)
-	}
-
-	// Modify the struct pattern to only match structs in this layer's packages
-	layerScopedStructPattern := l.getScopedPattern(structPattern)
-	return NewParameterRule(layerScopedStructPattern, methodPattern, parameterTypePattern, true)
+	return l.newParameterRule(structPattern, methodPattern, parameterTypePattern, true)
 }
 
 // MethodsShouldUseStructParameters creates a rule that methods in this layer should use struct parameters
 func (l *Layer) MethodsShouldUseStructParameters(structPattern, methodPattern, parameterTypePattern string) (*ParameterRule, error) {
-	if l.arch == nil {
-		return nil, fmt.Errorf("layer %q is not associated with an architecture", l.Name)
-	}
-
-	// Modify the struct pattern to only match structs in this layer's packages
-	layerScopedStructPattern := l.getScopedPattern(structPattern)
-	return NewParameterRule(layerScopedStructPattern, methodPattern, parameterTypePattern, false)
+	return l.newParameterRule(structPattern, methodPattern, parameterTypePattern, false)
 }
 
-// getScopedPattern prefixes the pattern with the layer's package patterns
-func (l *Layer) getScopedPattern(pattern string) string {
-	// If the pattern is already scoped to packages, leave it as is
-	if strings.HasPrefix(pattern, "^") {
-		return pattern
+// newParameterRule creates a parameter rule that only checks structs in this layer
+func (l *Layer) newParameterRule(structPattern, methodPattern, parameterTypePattern string, shouldUseInterface bool) (*ParameterRule, error) {
+	if l.arch == nil {
+		return nil, fmt.Errorf("layer %q is not associated with an architecture", l.Name)
 	}
 
-	// Simple approach: prefix the pattern with each package pattern
-	var scopedPatterns []string
-	for _, pkgPattern := range l.Packages {
-		// Remove ^ and $ from package pattern if present
-		pkgPattern = strings.TrimPrefix(pkgPattern, "^")
-		pkgPattern = strings.TrimSuffix(pkgPattern, "$")
-
-		// Combine the package pattern with the input pattern
-		scopedPatterns = append(scopedPatterns, fmt.Sprintf("^%s\\.%s", pkgPattern, pattern))
+	rule, err := NewParameterRule(structPattern, methodPattern, parameterTypePattern, shouldUseInterface)
+	if err != nil {
+		return nil, err
 	}
-
-	return "(" + strings.Join(scopedPatterns, "|") + ")"
+	rule.Layer = l
+	return rule, nil
 }
 
+// PolicyMode determines how a layered architecture treats dependencies between layers
+// that no rule mentions
+type PolicyMode int
+
+const (
+	// DenyByDefault reports every dependency between layers that no rule allows or that
+	// a rule forbids
+	DenyByDefault PolicyMode = iota
+	// AllowByDefault only reports dependencies between layers that a rule forbids,
+	// which allows introducing rules incrementally
+	AllowByDefault
+)
+
 // LayeredArchitecture represents a layered architecture with dependency rules
 type LayeredArchitecture struct {
-	Layers [](*Layer)
-	rules  [](*DependencyRule)
-	arch   *Architecture // Reference to the architecture
+	Layers           [](*Layer)
+	rules            [](*DependencyRule)
+	ruleNames        map[*DependencyRule]string // human-readable description of each rule
+	usedRules        map[*DependencyRule]bool   // allow rules exercised by an import during the last Check
+	violatedEdges    map[[2]*Layer]bool         // dependencies between layers reported during the last Check
+	arch             *Architecture              // Reference to the architecture
+	requireAllMapped bool                       // if true, packages outside every layer are violations
+	policy           PolicyMode                 // how dependencies without an allow rule are treated
+	compositionRoots map[*Layer]bool            // layers whose packages may import any layer
+	allowTransitive  bool                       // if true, chains of allowed layer dependencies imply the transitive ones
 }
 
 // NewLayeredArchitecture creates a new layered architecture
 func (a *Architecture) NewLayeredArchitecture(layers ...*Layer) *LayeredArchitecture {
 	layeredArch := &LayeredArchitecture{
-		Layers: layers,
-		rules:  make([]*DependencyRule, 0),
-		arch:   a,
+		Layers:    layers,
+		rules:     make([]*DependencyRule, 0),
+		ruleNames: make(map[*DependencyRule]string),
+		usedRules: make(map[*DependencyRule]bool),
+		arch:      a,
 	}
 	for _, layer := range layers {
 		layer.layeredArch = layeredArch
@@ -296,6 +574,24 @@ func (a *Architecture) NewLayeredArchitecture(layers ...*Layer) *LayeredArchitec
 	return layeredArch
 }
 
+// layeredPackageCount counts the parsed packages that belong to one of the layers
+func (la *LayeredArchitecture) layeredPackageCount() int {
+	if la.arch == nil {
+		return 0
+	}
+
+	count := 0
+	for pkgPath := range la.arch.Packages {
+		for _, layer := range la.Layers {
+			if layer.Contains(pkgPath) {
+				count++
+				break
+			}
+		}
+	}
+	return count
+}
+
 // WhereLayer returns a layer by name
 func (la *LayeredArchitecture) WhereLayer(name string) *Layer {
 	for _, layer := range la.Layers {
@@ -318,41 +614,271 @@ func (la *LayeredArchitecture) AddRule(sourceLayerName, targetLayerName string)
 		return fmt.Errorf("target layer %q not found", targetLayerName)
 	}
 
-	// Create patterns for all packages in source and target layers
-	for _, sourcePkg := range sourceLayer.Packages {
-		for _, targetPkg := range targetLayer.Packages {
-			// Clean up patterns by removing ^ and $ if present
-			sourceClean := strings.TrimPrefix(sourcePkg, "^")
-			sourceClean = strings.TrimSuffix(sourceClean, "$")
-			targetClean := strings.TrimPrefix(targetPkg, "^")
-			targetClean = strings.TrimSuffix(targetClean, "$")
+	rule, err := newLayerDependencyRule(sourceLayer, targetLayer, true)
+	if err != nil {
+		return err
+	}
+	la.rules = append(la.rules, rule)
+	la.ruleNames[rule] = fmt.Sprintf("layer %q may depend on layer %q", sourceLayer.Name, targetLayer.Name)
+
+	return nil
+}
+
+// RequireAllPackagesMapped makes Check report every parsed package that doesn't belong
+// to any layer, instead of silently skipping it
+func (la *LayeredArchitecture) RequireAllPackagesMapped(require bool) {
+	la.requireAllMapped = require
+}
+
+// SetPolicyMode sets how Check treats dependencies between layers. In the default
+// DenyByDefault mode every dependency needs an allow rule, while in AllowByDefault
+// mode only dependencies forbidden by a rule added through AddDependencyConstraint
+// or Layer.MustNotDependOnLayer are reported. Forbidding rules take precedence over
+// allow rules in both modes.
+func (la *LayeredArchitecture) SetPolicyMode(mode PolicyMode) {
+	la.policy = mode
+}
+
+// MarkCompositionRoot marks a layer as a composition root, such as the main packages
+// under cmd/ that wire all layers together. Its packages may import any layer without an
+// allow rule, while dependencies forbidden by a rule are still reported.
+func (la *LayeredArchitecture) MarkCompositionRoot(layerName string) error {
+	layer := la.WhereLayer(layerName)
+	if layer == nil {
+		return fmt.Errorf("layer %q not found", layerName)
+	}
+
+	if la.compositionRoots == nil {
+		la.compositionRoots = make(map[*Layer]bool)
+	}
+	la.compositionRoots[layer] = true
+	return nil
+}
+
+// AllowTransitive makes Check accept dependencies implied by a chain of allowed layer
+// dependencies, e.g. presentation -> domain if presentation -> application and
+// application -> domain are allowed. Only rules added through AddRule or DependsOnLayer
+// form chains, and they are followed in their direction only. Like any other dependency,
+// an implied one is still reported if a rule added through AddDependencyConstraint or
+// MustNotDependOnLayer forbids it.
+func (la *LayeredArchitecture) AllowTransitive(allow bool) {
+	la.allowTransitive = allow
+}
+
+// transitiveEdges returns every pair of layers connected by a chain of allowed layer dependencies
+func (la *LayeredArchitecture) transitiveEdges() map[[2]*Layer]bool {
+	next := make(map[*Layer][]*Layer)
+	for _, rule := range la.rules {
+		if rule.AllowedImports && rule.sourceLayer != nil && rule.targetLayer != nil {
+			next[rule.sourceLayer] = append(next[rule.sourceLayer], rule.targetLayer)
+		}
+	}
+
+	edges := make(map[[2]*Layer]bool)
+	for _, source := range la.Layers {
+		queue := append([]*Layer(nil), next[source]...)
+		for len(queue) > 0 {
+			target := queue[0]
+			queue = queue[1:]
+			if edges[[2]*Layer{source, target}] {
+				continue
+			}
+			edges[[2]*Layer{source, target}] = true
+			queue = append(queue, next[target]...)
+		}
+	}
+	return edges
+}
 
-			// Create patterns that match any path ending with the package name
-			sourcePattern := fmt.Sprintf("(^|/)%s$", sourceClean)
-			targetPattern := fmt.Sprintf("(^|/)%s$", targetClean)
+// Validate checks the layer definitions themselves and reports every parsed package that is
+// matched by more than one layer. Check assigns such packages to the first matching layer,
+// so overlapping patterns should be fixed before relying on its results. It also reports
+// pairs of layers with both a rule allowing and a rule forbidding the dependency, as well
+// as layer rules declared more than once.
+func (la *LayeredArchitecture) Validate() []string {
+	violations := []string{}
+	if la.arch == nil {
+		return append(violations, "layered architecture is not associated with an architecture")
+	}
 
-			rule, err := NewDependencyRule(sourcePattern, targetPattern, true)
-			if err != nil {
-				return err
+	pkgPaths := make([]string, 0, len(la.arch.Packages))
+	for pkgPath := range la.arch.Packages {
+		pkgPaths = append(pkgPaths, pkgPath)
+	}
+	sort.Strings(pkgPaths)
+
+	for _, pkgPath := range pkgPaths {
+		layerNames := []string{}
+		for _, layer := range la.Layers {
+			if layer.Contains(pkgPath) {
+				layerNames = append(layerNames, fmt.Sprintf("%q", layer.Name))
 			}
-			la.rules = append(la.rules, rule)
+		}
+
+		if len(layerNames) > 1 {
+			violations = append(violations, fmt.Sprintf(
+				"Package %q is matched by multiple layers: %s",
+				pkgPath, strings.Join(layerNames, ", "),
+			))
 		}
 	}
 
-	return nil
+	return append(violations, la.conflictingRules()...)
+}
+
+// conflictingRules reports, in the order the rules were added, layer pairs whose dependency
+// is both allowed and forbidden, and layer rules that repeat an earlier one. Forbidding
+// rules take precedence in Check, so the allow rule of a conflict has no effect.
+func (la *LayeredArchitecture) conflictingRules() []string {
+	problems := []string{}
+	declared := make(map[[2]*Layer]map[bool]*DependencyRule)
+	for _, rule := range la.rules {
+		if rule.sourceLayer == nil || rule.targetLayer == nil || rule.Exclusive || rule.CaptureGroup != "" {
+			continue
+		}
+
+		pair := [2]*Layer{rule.sourceLayer, rule.targetLayer}
+		if declared[pair] == nil {
+			declared[pair] = make(map[bool]*DependencyRule)
+		}
+		if declared[pair][rule.AllowedImports] != nil {
+			problems = append(problems, fmt.Sprintf("Rule %s is declared more than once", la.ruleNames[rule]))
+			continue
+		}
+		if opposite := declared[pair][!rule.AllowedImports]; opposite != nil {
+			problems = append(problems, fmt.Sprintf(
+				"Rules %s and %s contradict each other",
+				la.ruleNames[opposite], la.ruleNames[rule],
+			))
+		}
+		declared[pair][rule.AllowedImports] = rule
+	}
+	return problems
+}
+
+// AssertDisjoint reports every parsed package that belongs to both layers, e.g. to keep
+// the packages of two deployment units apart. Unlike Validate, it also reports packages
+// that Check assigns to another layer matched before either of the two.
+func (la *LayeredArchitecture) AssertDisjoint(layerA, layerB string) []string {
+	violations := []string{}
+	if la.arch == nil {
+		return append(violations, "layered architecture is not associated with an architecture")
+	}
+
+	first := la.WhereLayer(layerA)
+	if first == nil {
+		return append(violations, fmt.Sprintf("layer %q not found", layerA))
+	}
+	second := la.WhereLayer(layerB)
+	if second == nil {
+		return append(violations, fmt.Sprintf("layer %q not found", layerB))
+	}
+
+	pkgPaths := make([]string, 0, len(la.arch.Packages))
+	for pkgPath := range la.arch.Packages {
+		pkgPaths = append(pkgPaths, pkgPath)
+	}
+	sort.Strings(pkgPaths)
+
+	for _, pkgPath := range pkgPaths {
+		if first.Contains(pkgPath) && second.Contains(pkgPath) {
+			violations = append(violations, fmt.Sprintf(
+				"Package %q belongs to both layer %q and layer %q",
+				pkgPath, first.Name, second.Name,
+			))
+		}
+	}
+
+	return violations
 }
 
 // AddDependencyConstraint adds a dependency constraint rule directly to the layered architecture
 func (la *LayeredArchitecture) AddDependencyConstraint(rule *DependencyRule) {
 	la.rules = append(la.rules, rule)
+	verb := "must not depend on"
+	switch {
+	case rule.Exclusive:
+		verb = "may only depend on"
+	case rule.AllowedImports:
+		verb = "may depend on"
+	}
+	la.ruleNames[rule] = fmt.Sprintf("%s %s %s", rule.SourcePattern, verb, rule.TargetPattern)
+}
+
+// UnusedRules reports every allow rule that no import exercised during the most recent
+// Check, so that obsolete rules don't silently widen what is permitted. A layer rule is
+// reported once, even though it covers every package pattern of both layers.
+func (la *LayeredArchitecture) UnusedRules() []string {
+	used := make(map[string]bool)
+	for _, rule := range la.rules {
+		if la.usedRules[rule] {
+			used[la.ruleNames[rule]] = true
+		}
+	}
+
+	unused := []string{}
+	reported := make(map[string]bool)
+	for _, rule := range la.rules {
+		name := la.ruleNames[rule]
+		if !rule.AllowedImports || used[name] || reported[name] {
+			continue
+		}
+		reported[name] = true
+		unused = append(unused, fmt.Sprintf("Rule %s is not exercised by any import", name))
+	}
+
+	return unused
 }
 
-// Check checks the architecture against the defined layers and rules
+// Check checks the architecture against the defined layers and rules.
+// It returns an error if the layered architecture was not created through
+// Architecture.NewLayeredArchitecture and therefore has no architecture to check.
 func (la *LayeredArchitecture) Check() ([]string, error) {
-	violations := []string{}
+	violations, err := la.CheckDetailed()
+	if err != nil {
+		return nil, err
+	}
+	return violationStrings(violations), nil
+}
+
+// CheckDetailed checks the architecture against the defined layers and rules
+// and returns structured violations
+func (la *LayeredArchitecture) CheckDetailed() ([]Violation, error) {
+	if la.arch == nil {
+		return nil, fmt.Errorf("layered architecture is not associated with an architecture")
+	}
+	return la.check(nil), nil
+}
+
+// CheckChanged checks the architecture like Check, but only reports violations of the
+// given packages, e.g. those touched by a change. Imports are still resolved against all
+// parsed packages. Packages may be given by their path or their full import path.
+// Afterwards, UnusedRules only takes the imports of the given packages into account.
+func (la *LayeredArchitecture) CheckChanged(changedPkgs []string) ([]string, error) {
+	if la.arch == nil {
+		return nil, fmt.Errorf("layered architecture is not associated with an architecture")
+	}
+	return violationStrings(la.check(la.arch.changedSet(changedPkgs))), nil
+}
+
+// check checks the architecture against the defined layers and rules
+// If sources is not nil, only the packages it contains are checked.
+func (la *LayeredArchitecture) check(sources map[string]bool) []Violation {
+	violations := []Violation{}
+	la.usedRules = make(map[*DependencyRule]bool)
+	la.violatedEdges = make(map[[2]*Layer]bool)
+
+	var implied map[[2]*Layer]bool
+	if la.allowTransitive {
+		implied = la.transitiveEdges()
+	}
 
 	// For each package, check which layer it belongs to
 	for pkgPath, pkg := range la.arch.Packages {
+		if sources != nil && !sources[pkgPath] {
+			continue
+		}
+
 		var sourceLayer *Layer
 		for _, layer := range la.Layers {
 			if layer.Contains(pkgPath) {
@@ -362,34 +888,41 @@ func (la *LayeredArchitecture) Check() ([]string, error) {
 		}
 
 		if sourceLayer == nil {
+			if la.requireAllMapped {
+				violations = append(violations, newViolation(RuleTypeLayer, token.Position{}, pkgPath, "",
+					"Package %q does not belong to any layer", pkgPath,
+				))
+			}
 			// Skip packages that don't belong to any layer
 			continue
 		}
 
 		// Check each import
-		for _, importPath := range pkg.Imports {
-			// Skip standard library imports that don't have dots or slashes
-			// (this generally means they're from the standard library)
-			if !strings.Contains(importPath, ".") && !strings.Contains(importPath, "/") {
+		for idx, importPath := range pkg.Imports {
+			// Skip standard library imports, so that e.g. net/http never ends up in a layer
+			// matching "http"
+			if IsStandardLibrary(importPath) {
 				continue
 			}
 
-			// Find which layer the import belongs to
+			// Find which layer the import belongs to, preferring the package path
+			// the import resolves to within the module
 			var targetLayer *Layer
-			for _, layer := range la.Layers {
-				// Check if this import belongs to the layer
-				for _, pattern := range layer.patterns {
-					// Improve matching to detect the layer based on the import path
-					// For packages like github.com/mstrYoda/go-arctest/examples/example_project/utils
-					// we want to match against the "utils" part
-					if pattern.MatchString(importPath) ||
-						strings.HasSuffix(importPath, "/"+strings.TrimPrefix(strings.TrimSuffix(layer.Packages[0], "$"), "^")) {
+			if resolved, ok := la.arch.ResolveImport(importPath); ok {
+				for _, layer := range la.Layers {
+					if layer.matches(resolved) {
 						targetLayer = layer
 						break
 					}
 				}
-				if targetLayer != nil {
-					break
+			}
+
+			if targetLayer == nil {
+				for _, layer := range la.Layers {
+					if layer.matchesImport(importPath) {
+						targetLayer = layer
+						break
+					}
 				}
 			}
 
@@ -403,27 +936,42 @@ func (la *LayeredArchitecture) Check() ([]string, error) {
 				continue
 			}
 
-			// Check if this import is allowed by rules
-			allowed := false
-			for _, rule := range la.rules {
-				if rule.sourcePatternRegex.MatchString(pkgPath) &&
-					rule.targetPatternRegex.MatchString(importPath) &&
-					rule.AllowedImports {
-					allowed = true
-					break
-				}
+			// Forbidden dependencies are violations whatever the policy and the allow rules
+			forbidden, allowed := la.arch.evaluateImport(la.rules, pkgPath, importPath)
+			if len(forbidden) > 0 {
+				violations = append(violations, newViolation(RuleTypeLayer, pkg.importPosition(idx), pkgPath, importPath,
+					"Package %q in layer %q imports %q in layer %q, but a rule forbids this dependency",
+					pkgPath, sourceLayer.Name, importPath, targetLayer.Name,
+				).withSeverity(forbidden[0].rule.Severity))
+				la.violatedEdges[[2]*Layer{sourceLayer, targetLayer}] = true
+				continue
 			}
 
-			if !allowed {
-				violations = append(violations, fmt.Sprintf(
-					"Package %q in layer %q imports %q in layer %q, but no rule allows this dependency",
-					pkgPath, sourceLayer.Name, importPath, targetLayer.Name,
-				))
+			// Record every allow rule the import exercises, whatever the policy
+			for _, rule := range allowed {
+				la.usedRules[rule] = true
+			}
+
+			// Without a default deny, only explicitly forbidden dependencies are violations.
+			// Composition roots are exempt from the default deny as well.
+			if la.policy == AllowByDefault || la.compositionRoots[sourceLayer] {
+				continue
+			}
+
+			// Dependencies implied by a chain of allowed ones are fine as well
+			if len(allowed) > 0 || implied[[2]*Layer{sourceLayer, targetLayer}] {
+				continue
 			}
+
+			violations = append(violations, newViolation(RuleTypeLayer, pkg.importPosition(idx), pkgPath, importPath,
+				"Package %q in layer %q imports %q in layer %q, but no rule allows this dependency",
+				pkgPath, sourceLayer.Name, importPath, targetLayer.Name,
+			))
+			la.violatedEdges[[2]*Layer{sourceLayer, targetLayer}] = true
 		}
 	}
 
-	return violations, nil
+	return violations
 }
 
 // DependsOn creates a rule that one package pattern depends on another
@@ -436,8 +984,129 @@ func (a *Architecture) DoesNotDependOn(sourcePattern, targetPattern string) (*De
 	return NewDependencyRule(sourcePattern, targetPattern, false)
 }
 
+// MayOnlyDependOn creates a rule that packages matching the source pattern may only import
+// the standard library and packages whose import path matches one of the allowed target patterns
+func (a *Architecture) MayOnlyDependOn(sourcePattern string, allowedTargets ...string) (*DependencyRule, error) {
+	if len(allowedTargets) == 0 {
+		return nil, fmt.Errorf("at least one allowed target pattern is required")
+	}
+
+	patterns := make([]string, 0, len(allowedTargets))
+	for _, target := range allowedTargets {
+		if _, err := regexp.Compile(target); err != nil {
+			return nil, fmt.Errorf("invalid target pattern: %w", err)
+		}
+		patterns = append(patterns, "(?:"+target+")")
+	}
+
+	rule, err := NewDependencyRule(sourcePattern, strings.Join(patterns, "|"), true)
+	if err != nil {
+		return nil, err
+	}
+	rule.Exclusive = true
+
+	return rule, nil
+}
+
+// CheckChanged checks the dependencies of the given packages against the rules, e.g. only
+// those of packages touched by a change, so that unrelated existing violations aren't
+// reported. Packages may be given by their path or their full import path.
+func (a *Architecture) CheckChanged(changedPkgs []string, rules ...*DependencyRule) []string {
+	return violationStrings(a.checkDependencies(rules, a.changedSet(changedPkgs)))
+}
+
+// changedSet maps changed packages to the paths of the parsed packages they refer to,
+// including their external test packages
+func (a *Architecture) changedSet(changedPkgs []string) map[string]bool {
+	changed := make(map[string]bool, len(changedPkgs))
+	for _, pkg := range changedPkgs {
+		if resolved, ok := a.ResolveImport(pkg); ok {
+			pkg = resolved
+		}
+		changed[pkg] = true
+		changed[pkg+"_test"] = true
+	}
+	return changed
+}
+
+// DoesNotDependOnAcross creates a rule that packages matching the source pattern must not
+// import packages matching the target pattern for which the named group captures a different
+// value, e.g. to keep subdomains apart with the group "sd" in the source pattern
+// `^(?P<sd>[^/]+)/domain` and the target pattern `^(?P<sd>[^/]+)/infrastructure`.
+// Targets are matched by the package path they resolve to, or their import path otherwise.
+func (a *Architecture) DoesNotDependOnAcross(sourcePattern, targetPattern, group string) (*DependencyRule, error) {
+	return newCaptureRule(sourcePattern, targetPattern, group, false)
+}
+
+// DoesNotDependOnWithin creates a rule that packages matching the source pattern must not
+// import packages matching the target pattern for which the named group captures the same value
+func (a *Architecture) DoesNotDependOnWithin(sourcePattern, targetPattern, group string) (*DependencyRule, error) {
+	return newCaptureRule(sourcePattern, targetPattern, group, true)
+}
+
+// newCaptureRule creates a rule forbidding dependencies depending on the values of a named group
+func newCaptureRule(sourcePattern, targetPattern, group string, same bool) (*DependencyRule, error) {
+	if group == "" {
+		return nil, fmt.Errorf("capture group name cannot be empty")
+	}
+
+	rule, err := NewDependencyRule(sourcePattern, targetPattern, false)
+	if err != nil {
+		return nil, err
+	}
+	if rule.sourcePatternRegex.SubexpIndex(group) < 0 {
+		return nil, fmt.Errorf("source pattern %q has no capture group %q", sourcePattern, group)
+	}
+	if rule.targetPatternRegex.SubexpIndex(group) < 0 {
+		return nil, fmt.Errorf("target pattern %q has no capture group %q", targetPattern, group)
+	}
+	rule.CaptureGroup = group
+	rule.SameCapture = same
+
+	return rule, nil
+}
+
+// checkCaptureRule checks an import against a capture rule and describes the rule if it forbids the import
+func (a *Architecture) checkCaptureRule(rule *DependencyRule, pkgPath, importPath string) (string, bool) {
+	source := rule.sourcePatternRegex.FindStringSubmatch(pkgPath)
+	if source == nil {
+		return "", false
+	}
+
+	target := importPath
+	if resolved, ok := a.ResolveImport(importPath); ok {
+		target = resolved
+	}
+	targetMatch := rule.targetPatternRegex.FindStringSubmatch(target)
+	if targetMatch == nil {
+		return "", false
+	}
+
+	sourceValue := source[rule.sourcePatternRegex.SubexpIndex(rule.CaptureGroup)]
+	targetValue := targetMatch[rule.targetPatternRegex.SubexpIndex(rule.CaptureGroup)]
+	if (sourceValue == targetValue) != rule.SameCapture {
+		return "", false
+	}
+
+	relation := "a different"
+	if rule.SameCapture {
+		relation = "the same"
+	}
+	return fmt.Sprintf("%s cannot import %s with %s %s (%q and %q)",
+		rule.SourcePattern, rule.TargetPattern, relation, rule.CaptureGroup, sourceValue, targetValue,
+	), true
+}
+
 // ValidateDependenciesWithRules validates dependencies against the provided rules
+// Rules with SeverityWarning are reported but don't make the validation fail.
 func (a *Architecture) ValidateDependenciesWithRules(rules []*DependencyRule) (bool, []string) {
-	violations, _ := a.CheckDependencies(rules)
-	return len(violations) == 0, violations
+	valid, violations := a.ValidateDependenciesWithRulesDetailed(rules)
+	return valid, violationStrings(violations)
+}
+
+// ValidateDependenciesWithRulesDetailed validates dependencies against the provided rules
+// and returns structured violations
+func (a *Architecture) ValidateDependenciesWithRulesDetailed(rules []*DependencyRule) (bool, []Violation) {
+	violations := a.checkDependencies(rules, nil)
+	return !HasErrors(violations), violations
 }
@@ -0,0 +1,79 @@
+package arctest
+
+import (
+	"go/token"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// MaxPackageDepth reports packages whose path relative to the base path has more than
+// maxDepth elements. Paths below one of the given roots, such as "cmd" or "internal", are
+// counted from that root instead, using the longest matching root. Deeply nested packages
+// often indicate a missing module boundary.
+func (a *Architecture) MaxPackageDepth(maxDepth int, roots ...string) []string {
+	return violationStrings(a.checkPackageDepth(maxDepth, roots))
+}
+
+// MaxPackageDepthDetailed reports packages nested deeper than maxDepth below the base
+// path or one of the roots and returns structured violations
+func (a *Architecture) MaxPackageDepthDetailed(maxDepth int, roots ...string) []Violation {
+	return a.checkPackageDepth(maxDepth, roots)
+}
+
+// checkPackageDepth flags packages nested deeper than maxDepth
+func (a *Architecture) checkPackageDepth(maxDepth int, roots []string) []Violation {
+	violations := []Violation{}
+
+	pkgPaths := make([]string, 0, len(a.Packages))
+	for pkgPath, pkg := range a.Packages {
+		// External test packages share the directory of the package they test
+		if !pkg.IsTest {
+			pkgPaths = append(pkgPaths, pkgPath)
+		}
+	}
+	sort.Strings(pkgPaths)
+
+	for _, pkgPath := range pkgPaths {
+		root, depth := packageDepth(filepath.ToSlash(pkgPath), roots)
+		if depth <= maxDepth {
+			continue
+		}
+
+		if root == "" {
+			violations = append(violations, newViolation(RuleTypePackageDepth, token.Position{}, pkgPath, "",
+				"Package %q is nested %d levels deep, but at most %d are allowed",
+				pkgPath, depth, maxDepth,
+			))
+		} else {
+			violations = append(violations, newViolation(RuleTypePackageDepth, token.Position{}, pkgPath, "",
+				"Package %q is nested %d levels below %q, but at most %d are allowed",
+				pkgPath, depth, root, maxDepth,
+			))
+		}
+	}
+
+	return violations
+}
+
+// packageDepth returns the longest root containing a slash-separated package path and the
+// number of path elements below it. The root is empty if the depth counts from the base path.
+func packageDepth(pkgPath string, roots []string) (string, int) {
+	if pkgPath == "." || pkgPath == "" {
+		return "", 0
+	}
+
+	base := ""
+	for _, root := range roots {
+		root = strings.Trim(filepath.ToSlash(root), "/")
+		if root != "" && len(root) > len(base) && (pkgPath == root || strings.HasPrefix(pkgPath, root+"/")) {
+			base = root
+		}
+	}
+
+	rel := strings.TrimPrefix(strings.TrimPrefix(pkgPath, base), "/")
+	if rel == "" {
+		return base, 0
+	}
+	return base, strings.Count(rel, "/") + 1
+}
@@ -0,0 +1,174 @@
+package arctest
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// layerColors is the palette used to color packages by layer in DOT output
+var layerColors = []string{
+	"#8dd3c7", "#ffffb3", "#bebada", "#fb8072", "#80b1d3",
+	"#fdb462", "#b3de69", "#fccde5", "#d9d9d9", "#bc80bd",
+}
+
+// ExportDOT writes the package dependency graph in Graphviz DOT format.
+// Every parsed package becomes a node; imports of packages outside the architecture are omitted.
+func (a *Architecture) ExportDOT(w io.Writer) error {
+	return a.ExportDOTWithLayers(w, nil)
+}
+
+// ExportDOTWithLayers writes the package dependency graph in Graphviz DOT format,
+// coloring each package by the first layer of the layered architecture that contains it.
+// Packages that don't belong to any layer are drawn without a fill color.
+func (a *Architecture) ExportDOTWithLayers(w io.Writer, la *LayeredArchitecture) error {
+	graph := a.internalDependencies()
+
+	nodes := make([]string, 0, len(graph))
+	for node := range graph {
+		nodes = append(nodes, node)
+	}
+	sort.Strings(nodes)
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "digraph architecture {")
+	fmt.Fprintln(bw, "\trankdir=LR;")
+	fmt.Fprintln(bw, "\tnode [shape=box];")
+
+	for _, node := range nodes {
+		fmt.Fprintf(bw, "\t%q [%s];\n", node, nodeAttrs(node, la))
+	}
+
+	for _, node := range nodes {
+		for _, target := range graph[node] {
+			fmt.Fprintf(bw, "\t%q -> %q;\n", node, target)
+		}
+	}
+
+	fmt.Fprintln(bw, "}")
+	return bw.Flush()
+}
+
+// nodeAttrs returns the DOT attributes of a package node, filled with the color of the
+// first layer containing the package if a layered architecture is given
+func nodeAttrs(node string, la *LayeredArchitecture) string {
+	attrs := fmt.Sprintf("label=%q", node)
+	if la == nil {
+		return attrs
+	}
+	for idx, layer := range la.Layers {
+		if layer.Contains(node) {
+			attrs += fmt.Sprintf(", style=filled, fillcolor=%q, tooltip=%q",
+				layerColors[idx%len(layerColors)], layer.Name)
+			break
+		}
+	}
+	return attrs
+}
+
+// ExportViolationGraphDOT writes only the packages and dependencies involved in the given
+// violations in Graphviz DOT format, e.g. those returned by CheckDetailed. Packages are
+// colored by layer, and each edge is labeled with the number of violations it stands for.
+func (la *LayeredArchitecture) ExportViolationGraphDOT(violations []Violation, w io.Writer) error {
+	if la.arch == nil {
+		return fmt.Errorf("layered architecture is not associated with an architecture")
+	}
+
+	nodes := map[string]bool{}
+	edges := map[[2]string]int{}
+	for _, v := range violations {
+		if v.SourcePackage == "" {
+			continue
+		}
+		nodes[v.SourcePackage] = true
+		if v.TargetPackage == "" {
+			continue
+		}
+
+		// Targets are reported by import path, nodes are named by package path
+		target := v.TargetPackage
+		if resolved, ok := la.arch.ResolveImport(target); ok {
+			target = resolved
+		}
+		nodes[target] = true
+		edges[[2]string{v.SourcePackage, target}]++
+	}
+
+	sortedNodes := make([]string, 0, len(nodes))
+	for node := range nodes {
+		sortedNodes = append(sortedNodes, node)
+	}
+	sort.Strings(sortedNodes)
+
+	sortedEdges := make([][2]string, 0, len(edges))
+	for edge := range edges {
+		sortedEdges = append(sortedEdges, edge)
+	}
+	sort.Slice(sortedEdges, func(x, y int) bool {
+		if sortedEdges[x][0] != sortedEdges[y][0] {
+			return sortedEdges[x][0] < sortedEdges[y][0]
+		}
+		return sortedEdges[x][1] < sortedEdges[y][1]
+	})
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "digraph violations {")
+	fmt.Fprintln(bw, "\trankdir=LR;")
+	fmt.Fprintln(bw, "\tnode [shape=box];")
+
+	for _, node := range sortedNodes {
+		fmt.Fprintf(bw, "\t%q [%s];\n", node, nodeAttrs(node, la))
+	}
+	for _, edge := range sortedEdges {
+		fmt.Fprintf(bw, "\t%q -> %q [color=red, label=\"%d\"];\n", edge[0], edge[1], edges[edge])
+	}
+
+	fmt.Fprintln(bw, "}")
+	return bw.Flush()
+}
+
+// ExportMermaid writes the layers and the dependencies allowed between them as a Mermaid
+// flowchart. Dependencies between layers reported by the last Check are drawn in red.
+func (la *LayeredArchitecture) ExportMermaid(w io.Writer) error {
+	ids := make(map[*Layer]string, len(la.Layers))
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "graph TD")
+	for idx, layer := range la.Layers {
+		ids[layer] = fmt.Sprintf("L%d", idx)
+		fmt.Fprintf(bw, "    %s[\"%s\"]\n", ids[layer], strings.ReplaceAll(layer.Name, `"`, "#quot;"))
+	}
+
+	// Allowed dependencies, in the order their rules were added
+	edges := 0
+	allowed := make(map[[2]*Layer]bool)
+	for _, rule := range la.rules {
+		edge := [2]*Layer{rule.sourceLayer, rule.targetLayer}
+		if !rule.AllowedImports || ids[edge[0]] == "" || ids[edge[1]] == "" || allowed[edge] {
+			continue
+		}
+		allowed[edge] = true
+		fmt.Fprintf(bw, "    %s --> %s\n", ids[edge[0]], ids[edge[1]])
+		edges++
+	}
+
+	// Violations, in layer order
+	violated := []int{}
+	for _, source := range la.Layers {
+		for _, target := range la.Layers {
+			if !la.violatedEdges[[2]*Layer{source, target}] {
+				continue
+			}
+			fmt.Fprintf(bw, "    %s -->|violation| %s\n", ids[source], ids[target])
+			violated = append(violated, edges)
+			edges++
+		}
+	}
+	for _, idx := range violated {
+		fmt.Fprintf(bw, "    linkStyle %d stroke:red,stroke-width:2px\n", idx)
+	}
+
+	return bw.Flush()
+}
@@ -0,0 +1,124 @@
+package arctest
+
+import (
+	"go/token"
+	"sort"
+	"strings"
+)
+
+// DuplicateTypeNames maps the name of every struct, interface and named type declared in
+// more than one package to the sorted paths of the packages declaring it
+func (a *Architecture) DuplicateTypeNames() map[string][]string {
+	declaredIn := map[string][]string{}
+	for pkgPath, pkg := range a.Packages {
+		names := map[string]bool{}
+		for name := range pkg.Structs {
+			names[name] = true
+		}
+		for name := range pkg.Interfaces {
+			names[name] = true
+		}
+		for name := range pkg.NamedTypes {
+			names[name] = true
+		}
+		for name := range names {
+			declaredIn[name] = append(declaredIn[name], pkgPath)
+		}
+	}
+
+	duplicates := map[string][]string{}
+	for name, pkgPaths := range declaredIn {
+		if len(pkgPaths) > 1 {
+			sort.Strings(pkgPaths)
+			duplicates[name] = pkgPaths
+		}
+	}
+	return duplicates
+}
+
+// ruleTypeName returns the name rule patterns are matched against for a type of the
+// package, qualified by the package path if QualifiedNames is set
+func (a *Architecture) ruleTypeName(pkg *Package, name string) string {
+	if a.QualifiedNames {
+		return pkg.Path + "." + name
+	}
+	return name
+}
+
+// InterfaceRuleWarnings warns about interface names matched by the interface pattern of a
+// rule that are declared in several packages, which makes it unclear which interface
+// structs are checked against. The rule checks themselves don't report these warnings;
+// suites include them.
+func (a *Architecture) InterfaceRuleWarnings(rules []*InterfaceImplementationRule) []Violation {
+	warnings := []Violation{}
+	for _, rule := range rules {
+		// Interfaces of a single package can't be ambiguous
+		if rule.InterfacePackage != "" {
+			continue
+		}
+
+		declaredIn := map[string][]string{}
+		for _, pkg := range a.Packages {
+			for _, i := range pkg.Interfaces {
+				if rule.interfacePatternRegex.MatchString(a.ruleTypeName(pkg, i.Name)) {
+					declaredIn[i.Name] = append(declaredIn[i.Name], pkg.Path)
+				}
+			}
+		}
+		warnings = append(warnings, a.ambiguousNameWarnings(RuleTypeInterfaceImplementation, "Interface", rule.InterfacePattern, declaredIn)...)
+	}
+	return warnings
+}
+
+// ParameterRuleWarnings warns about struct names matched by the struct pattern of a
+// parameter rule that are declared in several packages. Like InterfaceRuleWarnings, only
+// suites include them.
+func (a *Architecture) ParameterRuleWarnings(rules []*ParameterRule) []Violation {
+	warnings := []Violation{}
+	for _, rule := range rules {
+		declaredIn := map[string][]string{}
+		for _, pkg := range a.Packages {
+			if rule.Layer != nil && !rule.Layer.Contains(pkg.Path) {
+				continue
+			}
+			for _, s := range pkg.Structs {
+				if !rule.structPatternRegex.MatchString(a.ruleTypeName(pkg, s.Name)) {
+					continue
+				}
+				if rule.ExportedOnly && !token.IsExported(s.Name) {
+					continue
+				}
+				declaredIn[s.Name] = append(declaredIn[s.Name], pkg.Path)
+			}
+		}
+		warnings = append(warnings, a.ambiguousNameWarnings(RuleTypeParameter, "Struct", rule.StructPattern, declaredIn)...)
+	}
+	return warnings
+}
+
+// ambiguousNameWarnings warns about type names matched by a rule pattern that are declared
+// in several packages. With QualifiedNames the pattern already tells them apart.
+func (a *Architecture) ambiguousNameWarnings(ruleType RuleType, kind, pattern string, declaredIn map[string][]string) []Violation {
+	if a.QualifiedNames {
+		return nil
+	}
+
+	names := make([]string, 0, len(declaredIn))
+	for name, pkgPaths := range declaredIn {
+		if len(pkgPaths) > 1 {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	warnings := []Violation{}
+	for _, name := range names {
+		pkgPaths := append([]string(nil), declaredIn[name]...)
+		sort.Strings(pkgPaths)
+		warnings = append(warnings, newViolation(ruleType, token.Position{}, "", "",
+			"%s pattern %q matches %q in packages %s; use WithQualifiedNames to tell them apart",
+			kind, pattern, name, strings.Join(pkgPaths, ", "),
+		).withSeverity(SeverityWarning))
+	}
+	return warnings
+}
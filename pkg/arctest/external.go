@@ -0,0 +1,217 @@
+package arctest
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// ExternalImportRule represents a rule that the packages of a layer may only import the
+// standard library, packages of their own module and an allow-list of external modules
+type ExternalImportRule struct {
+	Layer    *Layer   // layer whose packages are checked
+	Allowed  []string // allowed external module or package path prefixes, e.g. "github.com/google/uuid"
+	Severity Severity // severity of violations, SeverityError if empty
+}
+
+// MayOnlyImportExternal creates a rule that this layer may only import the standard library,
+// packages of its own module and external packages below one of the allowed paths
+func (l *Layer) MayOnlyImportExternal(allowed []string) *ExternalImportRule {
+	return &ExternalImportRule{
+		Layer:   l,
+		Allowed: allowed,
+	}
+}
+
+// MustNotImportModule creates a rule that no package in this layer may import the given
+// module or package path, e.g. "github.com/gin-gonic/gin" or "database/sql", or any of its subpackages
+func (l *Layer) MustNotImportModule(modulePrefix string) (*DependencyRule, error) {
+	if l.arch == nil {
+		return nil, fmt.Errorf("layer %q is not associated with an architecture", l.Name)
+	}
+
+	modulePrefix = strings.TrimSuffix(modulePrefix, "/")
+	if modulePrefix == "" {
+		return nil, fmt.Errorf("module path cannot be empty")
+	}
+
+	rule, err := NewDependencyRule(l.pattern(), "^"+regexp.QuoteMeta(modulePrefix)+"(/.*)?$", false)
+	if err != nil {
+		return nil, err
+	}
+	rule.sourceLayer = l
+	rule.matchStdlib = true
+
+	return rule, nil
+}
+
+// MustBeSelfContained reports every import of a package in this layer that refers to a
+// package outside the layer, whether of the own module or external. Standard library
+// imports are allowed; use MustBeSelfContainedStrict to report them as well.
+func (l *Layer) MustBeSelfContained() ([]string, error) {
+	violations, err := l.checkSelfContained(true)
+	if err != nil {
+		return nil, err
+	}
+	return violationStrings(violations), nil
+}
+
+// MustBeSelfContainedDetailed reports imports of packages outside this layer other than
+// the standard library and returns structured violations
+func (l *Layer) MustBeSelfContainedDetailed() ([]Violation, error) {
+	return l.checkSelfContained(true)
+}
+
+// MustBeSelfContainedStrict reports every import of a package in this layer that refers to
+// a package outside the layer, including standard library packages
+func (l *Layer) MustBeSelfContainedStrict() ([]string, error) {
+	violations, err := l.checkSelfContained(false)
+	if err != nil {
+		return nil, err
+	}
+	return violationStrings(violations), nil
+}
+
+// MustBeSelfContainedStrictDetailed reports imports of packages outside this layer,
+// including standard library packages, and returns structured violations
+func (l *Layer) MustBeSelfContainedStrictDetailed() ([]Violation, error) {
+	return l.checkSelfContained(false)
+}
+
+// checkSelfContained flags imports of packages outside the layer, skipping standard
+// library imports if allowStdlib is set
+func (l *Layer) checkSelfContained(allowStdlib bool) ([]Violation, error) {
+	if l.arch == nil {
+		return nil, fmt.Errorf("layer %q is not associated with an architecture", l.Name)
+	}
+
+	violations := []Violation{}
+
+	pkgPaths := make([]string, 0, len(l.arch.Packages))
+	for pkgPath := range l.arch.Packages {
+		pkgPaths = append(pkgPaths, pkgPath)
+	}
+	sort.Strings(pkgPaths)
+
+	for _, pkgPath := range pkgPaths {
+		if !l.Contains(pkgPath) {
+			continue
+		}
+
+		pkg := l.arch.Packages[pkgPath]
+		for idx, importPath := range pkg.Imports {
+			if IsStandardLibrary(importPath) {
+				if allowStdlib {
+					continue
+				}
+			} else if l.containsImport(importPath) {
+				continue
+			}
+
+			violations = append(violations, newViolation(RuleTypeDependency, pkg.importPosition(idx), pkgPath, importPath,
+				"Package %q in layer %q imports %q, but the layer must be self-contained",
+				pkgPath, l.Name, importPath,
+			))
+		}
+	}
+
+	return violations, nil
+}
+
+// allows checks if the rule permits importing the given external import path
+func (r *ExternalImportRule) allows(importPath string) bool {
+	for _, allowed := range r.Allowed {
+		allowed = strings.TrimSuffix(allowed, "/")
+		if importPath == allowed || strings.HasPrefix(importPath, allowed+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// isInternalImport checks if an import path refers to a package of the analyzed module
+func (a *Architecture) isInternalImport(importPath string) bool {
+	if a.ModulePath != "" {
+		return importPath == a.ModulePath || strings.HasPrefix(importPath, a.ModulePath+"/")
+	}
+	_, ok := a.ResolveImport(importPath)
+	return ok
+}
+
+// isExternalImport checks if an import path refers to a package that is neither part of
+// the standard library nor of the analyzed module
+func (a *Architecture) isExternalImport(importPath string) bool {
+	return !IsStandardLibrary(importPath) && !a.isInternalImport(importPath)
+}
+
+// ExternalImports maps the path of every package to the sorted, de-duplicated import
+// paths of the external packages it imports, that is packages that are neither part of
+// the standard library nor of the analyzed module
+func (a *Architecture) ExternalImports() map[string][]string {
+	external := make(map[string][]string, len(a.Packages))
+
+	for pkgPath, pkg := range a.Packages {
+		seen := make(map[string]bool)
+		imports := []string{}
+		for _, importPath := range pkg.Imports {
+			if seen[importPath] || !a.isExternalImport(importPath) {
+				continue
+			}
+			seen[importPath] = true
+			imports = append(imports, importPath)
+		}
+		sort.Strings(imports)
+		external[pkgPath] = imports
+	}
+
+	return external
+}
+
+// checkExternalImports checks that layers only import allowed external packages
+func (a *Architecture) checkExternalImports(rules []*ExternalImportRule) []Violation {
+	violations := []Violation{}
+
+	pkgPaths := make([]string, 0, len(a.Packages))
+	for pkgPath := range a.Packages {
+		pkgPaths = append(pkgPaths, pkgPath)
+	}
+	sort.Strings(pkgPaths)
+
+	for _, rule := range rules {
+		for _, pkgPath := range pkgPaths {
+			if !rule.Layer.Contains(pkgPath) {
+				continue
+			}
+
+			pkg := a.Packages[pkgPath]
+			for idx, importPath := range pkg.Imports {
+				if !a.isExternalImport(importPath) || rule.allows(importPath) {
+					continue
+				}
+
+				violations = append(violations, newViolation(RuleTypeDependency, pkg.importPosition(idx), pkgPath, importPath,
+					"Package %q in layer %q imports external package %q, which is not in the allow-list",
+					pkgPath, rule.Layer.Name, importPath,
+				).withSeverity(rule.Severity))
+			}
+		}
+	}
+
+	return violations
+}
+
+// ValidateExternalImports validates that layers only import the standard library, their own
+// module and allowed external packages
+// Rules with SeverityWarning are reported but don't make the validation fail.
+func (a *Architecture) ValidateExternalImports(rules []*ExternalImportRule) (bool, []string) {
+	valid, violations := a.ValidateExternalImportsDetailed(rules)
+	return valid, violationStrings(violations)
+}
+
+// ValidateExternalImportsDetailed validates that layers only import allowed external packages
+// and returns structured violations
+func (a *Architecture) ValidateExternalImportsDetailed(rules []*ExternalImportRule) (bool, []Violation) {
+	violations := a.checkExternalImports(rules)
+	return !HasErrors(violations), violations
+}
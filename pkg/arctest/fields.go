@@ -0,0 +1,141 @@
+package arctest
+
+import (
+	"fmt"
+	"go/token"
+	"regexp"
+)
+
+// FieldRule represents a rule for checking the types of struct fields, e.g. that the
+// dependencies of a service are held as interfaces
+type FieldRule struct {
+	StructPattern         string   // regex pattern for struct names
+	FieldNamePattern      string   // regex pattern for field names
+	FieldTypePattern      string   // regex pattern for field types to check
+	ShouldUseInterface    bool     // if true, fields should be interfaces, if false, they should be structs
+	Layer                 *Layer   // optional layer whose structs are checked
+	ExportedOnly          bool     // if true, unexported structs are skipped
+	Severity              Severity // severity of violations, SeverityError if empty
+	structPatternRegex    *regexp.Regexp
+	fieldNamePatternRegex *regexp.Regexp
+	fieldTypePatternRegex *regexp.Regexp
+}
+
+// NewFieldRule creates a new field rule
+func NewFieldRule(structPattern, fieldNamePattern, fieldTypePattern string, shouldUseInterface bool) (*FieldRule, error) {
+	structRegex, err := regexp.Compile(structPattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid struct pattern: %w", err)
+	}
+
+	fieldNameRegex, err := regexp.Compile(fieldNamePattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid field name pattern: %w", err)
+	}
+
+	fieldTypeRegex, err := regexp.Compile(fieldTypePattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid field type pattern: %w", err)
+	}
+
+	return &FieldRule{
+		StructPattern:         structPattern,
+		FieldNamePattern:      fieldNamePattern,
+		FieldTypePattern:      fieldTypePattern,
+		ShouldUseInterface:    shouldUseInterface,
+		structPatternRegex:    structRegex,
+		fieldNamePatternRegex: fieldNameRegex,
+		fieldTypePatternRegex: fieldTypeRegex,
+	}, nil
+}
+
+// CheckStructFields checks if struct fields match the required type (interface or struct)
+func (a *Architecture) CheckStructFields(rules []*FieldRule) []string {
+	return violationStrings(a.checkStructFields(rules))
+}
+
+// checkStructFields checks if struct fields match the required type (interface or struct).
+// Field types are classified like parameter types, see ParameterRule.
+func (a *Architecture) checkStructFields(rules []*FieldRule) []Violation {
+	violations := []Violation{}
+	interfaces, structs := a.typeKinds()
+
+	for _, rule := range rules {
+		for _, pkg := range a.Packages {
+			if rule.Layer != nil && !rule.Layer.Contains(pkg.Path) {
+				continue
+			}
+
+			for _, s := range pkg.Structs {
+				if !rule.structPatternRegex.MatchString(a.ruleTypeName(pkg, s.Name)) {
+					continue
+				}
+				if rule.ExportedOnly && !token.IsExported(s.Name) {
+					continue
+				}
+
+				for _, f := range s.Fields {
+					if !rule.fieldNamePatternRegex.MatchString(f.Name) {
+						continue
+					}
+
+					// Skip empty or primitive types
+					if f.Type == "" || isPrimitiveType(f.Type) {
+						continue
+					}
+
+					// Look at the named type behind pointers, slices, maps and channels
+					fieldType := elementTypeName(f.Type)
+					if !rule.fieldTypePatternRegex.MatchString(fieldType) {
+						continue
+					}
+
+					isInterface, isStruct := a.typeKind(pkg, fieldType, interfaces, structs)
+
+					// If we can't determine the type, skip it
+					if !isInterface && !isStruct {
+						continue
+					}
+
+					if rule.ShouldUseInterface && !isInterface {
+						violations = append(violations, newViolation(RuleTypeField, f.Position, s.Pkg.Path, "",
+							"Field %q of struct %q in package %q uses struct type %q, but should use an interface",
+							f.Name, s.Name, s.Pkg.Path, fieldType,
+						).withSeverity(rule.Severity))
+					} else if !rule.ShouldUseInterface && !isStruct {
+						violations = append(violations, newViolation(RuleTypeField, f.Position, s.Pkg.Path, "",
+							"Field %q of struct %q in package %q uses interface type %q, but should use a struct",
+							f.Name, s.Name, s.Pkg.Path, fieldType,
+						).withSeverity(rule.Severity))
+					}
+				}
+			}
+		}
+	}
+
+	return violations
+}
+
+// StructFieldsShouldUseInterfaces creates a rule that struct fields should use interface types
+func (a *Architecture) StructFieldsShouldUseInterfaces(structPattern, fieldNamePattern, fieldTypePattern string) (*FieldRule, error) {
+	return NewFieldRule(structPattern, fieldNamePattern, fieldTypePattern, true)
+}
+
+// StructFieldsShouldUseStructs creates a rule that struct fields should use struct types
+func (a *Architecture) StructFieldsShouldUseStructs(structPattern, fieldNamePattern, fieldTypePattern string) (*FieldRule, error) {
+	return NewFieldRule(structPattern, fieldNamePattern, fieldTypePattern, false)
+}
+
+// ValidateStructFields validates that struct fields match the required type
+// Rules with SeverityWarning are reported but don't make the validation fail.
+func (a *Architecture) ValidateStructFields(rules []*FieldRule) (bool, []string) {
+	valid, violations := a.ValidateStructFieldsDetailed(rules)
+	return valid, violationStrings(violations)
+}
+
+// ValidateStructFieldsDetailed validates that struct fields match the required type
+// and returns structured violations
+func (a *Architecture) ValidateStructFieldsDetailed(rules []*FieldRule) (bool, []Violation) {
+	violations := a.checkStructFields(rules)
+	return !HasErrors(violations), violations
+}
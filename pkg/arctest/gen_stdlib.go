@@ -0,0 +1,46 @@
+//go:build ignore
+
+// gen_stdlib generates stdlib_packages.go, the list of standard library packages that
+// IsStandardLibrary falls back to when GOROOT isn't available. Run it with go generate
+// using the newest Go release.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+func main() {
+	out, err := exec.Command("go", "list", "std").Output()
+	if err != nil {
+		log.Fatalf("failed to list standard library packages: %v", err)
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by gen_stdlib.go from %s; DO NOT EDIT.\n\n", runtime.Version())
+	b.WriteString("package arctest\n\n")
+	b.WriteString("// stdlibPackages lists the importable standard library packages\n")
+	b.WriteString("var stdlibPackages = map[string]bool{\n")
+	for _, pkg := range strings.Fields(string(out)) {
+		// Internal and vendored packages can't be imported from other modules
+		if strings.HasPrefix(pkg, "vendor/") || pkg == "internal" || strings.HasPrefix(pkg, "internal/") || strings.Contains(pkg, "/internal") {
+			continue
+		}
+		fmt.Fprintf(&b, "\t%q: true,\n", pkg)
+	}
+	b.WriteString("}\n")
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		log.Fatalf("failed to format the package list: %v", err)
+	}
+	if err := os.WriteFile("stdlib_packages.go", src, 0o644); err != nil {
+		log.Fatalf("failed to write the package list: %v", err)
+	}
+}
@@ -0,0 +1,51 @@
+package arctest
+
+import (
+	"fmt"
+	"sort"
+)
+
+// MustNotHaveMutableGlobals reports package-level variables declared in the packages of
+// this layer, so that only constants are used. Every name of a var declaration is reported,
+// including those of grouped declarations, except for the blank identifier used in
+// compile-time assertions such as var _ Repository = (*repository)(nil).
+func (l *Layer) MustNotHaveMutableGlobals() ([]string, error) {
+	violations, err := l.MustNotHaveMutableGlobalsDetailed()
+	if err != nil {
+		return nil, err
+	}
+	return violationStrings(violations), nil
+}
+
+// MustNotHaveMutableGlobalsDetailed reports package-level variables in the packages of
+// this layer and returns structured violations
+func (l *Layer) MustNotHaveMutableGlobalsDetailed() ([]Violation, error) {
+	if l.arch == nil {
+		return nil, fmt.Errorf("layer %q is not associated with an architecture", l.Name)
+	}
+
+	pkgPaths := make([]string, 0, len(l.arch.Packages))
+	for pkgPath := range l.arch.Packages {
+		pkgPaths = append(pkgPaths, pkgPath)
+	}
+	sort.Strings(pkgPaths)
+
+	violations := []Violation{}
+	for _, pkgPath := range pkgPaths {
+		if !l.Contains(pkgPath) {
+			continue
+		}
+
+		for _, v := range l.arch.Packages[pkgPath].Variables {
+			if v.Name == "_" {
+				continue
+			}
+			violations = append(violations, newViolation(RuleTypeGlobal, v.Position, pkgPath, "",
+				"Package %q in layer %q declares package-level variable %q, but only constants are allowed",
+				pkgPath, l.Name, v.Name,
+			))
+		}
+	}
+
+	return violations, nil
+}
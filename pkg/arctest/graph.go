@@ -0,0 +1,232 @@
+package arctest
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// internalDependencies returns the adjacency list of the package graph, restricted to
+// packages that are part of the architecture, with sorted and de-duplicated edges
+func (a *Architecture) internalDependencies() map[string][]string {
+	graph := make(map[string][]string, len(a.Packages))
+
+	for pkgPath, pkg := range a.Packages {
+		seen := make(map[string]bool)
+		targets := []string{}
+		for _, importPath := range pkg.Imports {
+			target, ok := a.ResolveImport(importPath)
+			if !ok || target == pkgPath || seen[target] || a.Packages[target] == nil {
+				continue
+			}
+			seen[target] = true
+			targets = append(targets, target)
+		}
+		sort.Strings(targets)
+		graph[pkgPath] = targets
+	}
+
+	return graph
+}
+
+// Dependencies returns the sorted paths of the packages of the architecture that the
+// given package imports. The package may be given by its path or its full import path.
+func (a *Architecture) Dependencies(pkgPath string) []string {
+	pkgPath, ok := a.ResolveImport(pkgPath)
+	if !ok || a.Packages[pkgPath] == nil {
+		return []string{}
+	}
+	return a.internalDependencies()[pkgPath]
+}
+
+// Dependents returns the sorted paths of the packages of the architecture that import
+// the given package. The package may be given by its path or its full import path.
+func (a *Architecture) Dependents(pkgPath string) []string {
+	pkgPath, ok := a.ResolveImport(pkgPath)
+	if !ok || a.Packages[pkgPath] == nil {
+		return []string{}
+	}
+	return a.internalDependents()[pkgPath]
+}
+
+// internalDependents returns the reverse adjacency list of the package graph, with
+// sorted edges and an entry for every package of the architecture
+func (a *Architecture) internalDependents() map[string][]string {
+	graph := a.internalDependencies()
+
+	dependents := make(map[string][]string, len(graph))
+	for pkgPath := range graph {
+		dependents[pkgPath] = []string{}
+	}
+	for source, targets := range graph {
+		for _, target := range targets {
+			dependents[target] = append(dependents[target], source)
+		}
+	}
+	for _, sources := range dependents {
+		sort.Strings(sources)
+	}
+
+	return dependents
+}
+
+// importCycle is a strongly connected group of packages, split into the shortest
+// import cycle through its smallest member and the other packages of the group
+type importCycle struct {
+	path   []string
+	others []string
+}
+
+// FindCycles returns every group of packages that import each other in a cycle.
+// Each group starts with the shortest import cycle through its lexicographically
+// smallest package, followed by any further packages taking part in the same cycle.
+func (a *Architecture) FindCycles() [][]string {
+	cycles := [][]string{}
+	for _, cycle := range a.importCycles() {
+		cycles = append(cycles, append(append([]string{}, cycle.path...), cycle.others...))
+	}
+	return cycles
+}
+
+// importCycles finds the strongly connected components of the package graph that
+// contain more than one package
+func (a *Architecture) importCycles() []importCycle {
+	graph := a.internalDependencies()
+
+	nodes := make([]string, 0, len(graph))
+	for node := range graph {
+		nodes = append(nodes, node)
+	}
+	sort.Strings(nodes)
+
+	// Tarjan's strongly connected components algorithm
+	index := 0
+	indices := make(map[string]int)
+	lowlinks := make(map[string]int)
+	onStack := make(map[string]bool)
+	stack := []string{}
+	components := [][]string{}
+
+	var strongConnect func(node string)
+	strongConnect = func(node string) {
+		indices[node] = index
+		lowlinks[node] = index
+		index++
+		stack = append(stack, node)
+		onStack[node] = true
+
+		for _, next := range graph[node] {
+			if _, visited := indices[next]; !visited {
+				strongConnect(next)
+				if lowlinks[next] < lowlinks[node] {
+					lowlinks[node] = lowlinks[next]
+				}
+			} else if onStack[next] && indices[next] < lowlinks[node] {
+				lowlinks[node] = indices[next]
+			}
+		}
+
+		if lowlinks[node] == indices[node] {
+			component := []string{}
+			for {
+				last := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[last] = false
+				component = append(component, last)
+				if last == node {
+					break
+				}
+			}
+			if len(component) > 1 {
+				components = append(components, component)
+			}
+		}
+	}
+
+	for _, node := range nodes {
+		if _, visited := indices[node]; !visited {
+			strongConnect(node)
+		}
+	}
+
+	cycles := make([]importCycle, 0, len(components))
+	for _, component := range components {
+		cycles = append(cycles, orderCycle(graph, component))
+	}
+
+	sort.Slice(cycles, func(i, j int) bool {
+		return cycles[i].path[0] < cycles[j].path[0]
+	})
+
+	return cycles
+}
+
+// orderCycle finds the shortest cycle through the smallest member of a strongly
+// connected component
+func orderCycle(graph map[string][]string, component []string) importCycle {
+	members := make(map[string]bool, len(component))
+	for _, node := range component {
+		members[node] = true
+	}
+
+	sorted := append([]string(nil), component...)
+	sort.Strings(sorted)
+	start := sorted[0]
+
+	// Breadth-first search from the start node back to itself
+	parents := map[string]string{}
+	queue := []string{start}
+	end := ""
+	for len(queue) > 0 && end == "" {
+		node := queue[0]
+		queue = queue[1:]
+		for _, next := range graph[node] {
+			if !members[next] {
+				continue
+			}
+			if next == start {
+				end = node
+				break
+			}
+			if _, seen := parents[next]; !seen {
+				parents[next] = node
+				queue = append(queue, next)
+			}
+		}
+	}
+
+	cycle := []string{}
+	for node := end; node != start; node = parents[node] {
+		cycle = append([]string{node}, cycle...)
+	}
+	cycle = append([]string{start}, cycle...)
+
+	inCycle := make(map[string]bool, len(cycle))
+	for _, node := range cycle {
+		inCycle[node] = true
+	}
+	others := []string{}
+	for _, node := range sorted {
+		if !inCycle[node] {
+			others = append(others, node)
+		}
+	}
+
+	return importCycle{path: cycle, others: others}
+}
+
+// HasNoCycles checks that the package graph is acyclic and describes every cycle found
+func (a *Architecture) HasNoCycles() (bool, []string) {
+	violations := []string{}
+
+	for _, cycle := range a.importCycles() {
+		description := fmt.Sprintf("Import cycle detected: %s -> %s",
+			strings.Join(cycle.path, " -> "), cycle.path[0])
+		if len(cycle.others) > 0 {
+			description += fmt.Sprintf(" (cycle also involves %s)", strings.Join(cycle.others, ", "))
+		}
+		violations = append(violations, description)
+	}
+
+	return len(violations) == 0, violations
+}
@@ -0,0 +1,164 @@
+package arctest
+
+import (
+	"go/ast"
+	"go/token"
+	"sort"
+	"strings"
+)
+
+// ignorePrefix starts comments that suppress violations, e.g. "//arctest:ignore layer,dependency"
+const ignorePrefix = "//arctest:ignore"
+
+// ruleTypeAliases maps alternative names accepted in ignore comments to rule types
+var ruleTypeAliases = map[string]RuleType{
+	"layered": RuleTypeLayer,
+}
+
+// parseRuleType maps a rule type name of an ignore comment to the rule type
+func parseRuleType(name string) (RuleType, bool) {
+	if ruleType, ok := ruleTypeAliases[name]; ok {
+		return ruleType, true
+	}
+	for _, ruleType := range ruleTypes {
+		if string(ruleType) == name {
+			return ruleType, true
+		}
+	}
+	return "", false
+}
+
+// IgnoreDirective is an //arctest:ignore comment, which suppresses violations of the listed
+// rule types in a file or on a line. A comment before the package clause covers the whole
+// file, a comment on its own line covers the line below and any other comment covers its
+// own line. Text after the rule types explains why the violation is accepted. Rule types
+// are named like the RuleType constants, e.g. "layer" (or "layered") and "dependency".
+type IgnoreDirective struct {
+	File      string
+	Line      int            // line the comment covers, 0 for the whole file
+	RuleTypes []RuleType     // rule types to suppress, empty for all of them
+	Unknown   []string       // unrecognized rule type names, reported by IgnoreWarnings
+	Position  token.Position // position of the comment itself
+}
+
+// covers checks if the directive suppresses the violation
+func (d *IgnoreDirective) covers(v Violation) bool {
+	if v.File == "" || v.File != d.File || (d.Line != 0 && d.Line != v.Line) {
+		return false
+	}
+	if len(d.RuleTypes) == 0 {
+		// A comment naming only unknown rule types must not suppress everything
+		return len(d.Unknown) == 0
+	}
+	for _, ruleType := range d.RuleTypes {
+		if ruleType == v.RuleType {
+			return true
+		}
+	}
+	return false
+}
+
+// parseIgnoreDirectives collects the //arctest:ignore comments of a parsed file. Unknown
+// rule types are recorded, so that IgnoreWarnings can report them instead of the comment
+// silently suppressing nothing.
+func parseIgnoreDirectives(fset *token.FileSet, file *ast.File, src []byte) []*IgnoreDirective {
+	directives := []*IgnoreDirective{}
+	for _, group := range file.Comments {
+		for _, c := range group.List {
+			rest := strings.TrimPrefix(c.Text, ignorePrefix)
+			if rest == c.Text || (rest != "" && rest[0] != ' ' && rest[0] != '\t') {
+				continue
+			}
+
+			pos := fset.Position(c.Pos())
+			d := &IgnoreDirective{File: pos.Filename, Position: pos}
+			if fields := strings.Fields(rest); len(fields) > 0 {
+				for _, name := range strings.Split(fields[0], ",") {
+					if name == "" {
+						continue
+					}
+					ruleType, ok := parseRuleType(name)
+					if !ok {
+						d.Unknown = append(d.Unknown, name)
+						continue
+					}
+					d.RuleTypes = append(d.RuleTypes, ruleType)
+				}
+			}
+
+			switch {
+			case c.Pos() < file.Package:
+				d.Line = 0
+			case onOwnLine(src, pos.Offset):
+				d.Line = pos.Line + 1
+			default:
+				d.Line = pos.Line
+			}
+			directives = append(directives, d)
+		}
+	}
+	return directives
+}
+
+// onOwnLine checks if only whitespace precedes the given offset on its line
+func onOwnLine(src []byte, offset int) bool {
+	for i := offset - 1; i >= 0 && src[i] != '\n'; i-- {
+		if src[i] != ' ' && src[i] != '\t' {
+			return false
+		}
+	}
+	return true
+}
+
+// FilterIgnored removes the violations suppressed by //arctest:ignore comments and returns
+// the remaining ones together with the number of suppressed violations. Only suites and
+// assertions apply it automatically. The Check, Validate and Detailed functions return
+// every violation, so their results have to be passed through it explicitly.
+func (a *Architecture) FilterIgnored(violations []Violation) ([]Violation, int) {
+	byFile := make(map[string][]*IgnoreDirective)
+	for _, pkg := range a.Packages {
+		for _, d := range pkg.Ignores {
+			byFile[d.File] = append(byFile[d.File], d)
+		}
+	}
+
+	kept := make([]Violation, 0, len(violations))
+	suppressed := 0
+	for _, v := range violations {
+		ignored := false
+		for _, d := range byFile[v.File] {
+			if d.covers(v) {
+				ignored = true
+				break
+			}
+		}
+		if ignored {
+			suppressed++
+			continue
+		}
+		kept = append(kept, v)
+	}
+	return kept, suppressed
+}
+
+// IgnoreWarnings reports the unknown rule types of //arctest:ignore comments as warnings,
+// sorted by file and line. Suites report them automatically.
+func (a *Architecture) IgnoreWarnings() []Violation {
+	warnings := []Violation{}
+	for pkgPath, pkg := range a.Packages {
+		for _, d := range pkg.Ignores {
+			for _, name := range d.Unknown {
+				warnings = append(warnings, newViolation(RuleTypeIgnore, d.Position, pkgPath, "",
+					"Unknown rule type %q in %s comment", name, ignorePrefix,
+				).withSeverity(SeverityWarning))
+			}
+		}
+	}
+	sort.SliceStable(warnings, func(i, j int) bool {
+		if warnings[i].File != warnings[j].File {
+			return warnings[i].File < warnings[j].File
+		}
+		return warnings[i].Line < warnings[j].Line
+	})
+	return warnings
+}
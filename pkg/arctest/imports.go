@@ -0,0 +1,104 @@
+package arctest
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// ImportStyleRule represents a rule restricting how packages are imported
+type ImportStyleRule struct {
+	PackagePattern      string   // regex pattern for the importing packages the rule applies to
+	ForbidDotImports    bool     // if true, dot-imports are violations
+	ForbidBlankImports  bool     // if true, blank imports are violations outside of AllowBlankImports
+	AllowBlankImports   []string // regex patterns for packages that may use blank imports
+	Severity            Severity // severity of violations, SeverityError if empty
+	packagePatternRegex *regexp.Regexp
+	allowBlankRegexes   []*regexp.Regexp
+}
+
+// NewImportStyleRule creates a new import style rule. The allowBlankImports patterns are
+// matched against both the path and the name of the importing package, so "^main$" allows
+// blank imports of e.g. database drivers in every main package.
+func NewImportStyleRule(packagePattern string, forbidDotImports, forbidBlankImports bool, allowBlankImports ...string) (*ImportStyleRule, error) {
+	packageRegex, err := regexp.Compile(packagePattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid package pattern: %w", err)
+	}
+
+	allowRegexes := make([]*regexp.Regexp, 0, len(allowBlankImports))
+	for _, pattern := range allowBlankImports {
+		allowRegex, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid blank import pattern %q: %w", pattern, err)
+		}
+		allowRegexes = append(allowRegexes, allowRegex)
+	}
+
+	return &ImportStyleRule{
+		PackagePattern:      packagePattern,
+		ForbidDotImports:    forbidDotImports,
+		ForbidBlankImports:  forbidBlankImports,
+		AllowBlankImports:   allowBlankImports,
+		packagePatternRegex: packageRegex,
+		allowBlankRegexes:   allowRegexes,
+	}, nil
+}
+
+// allowsBlankImports checks if a package is allowed to use blank imports
+func (r *ImportStyleRule) allowsBlankImports(pkg *Package) bool {
+	for _, pattern := range r.allowBlankRegexes {
+		if pattern.MatchString(pkg.Path) || pattern.MatchString(pkg.Name) {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckImportStyle checks all packages against the provided import style rules
+func (a *Architecture) CheckImportStyle(rules []*ImportStyleRule) ([]string, error) {
+	return violationStrings(a.checkImportStyle(rules)), nil
+}
+
+// checkImportStyle checks all packages against the provided import style rules
+func (a *Architecture) checkImportStyle(rules []*ImportStyleRule) []Violation {
+	violations := []Violation{}
+
+	for _, rule := range rules {
+		for pkgPath, pkg := range a.Packages {
+			if !rule.packagePatternRegex.MatchString(pkgPath) {
+				continue
+			}
+
+			for _, spec := range pkg.ImportSpecs {
+				switch {
+				case spec.Kind == ImportDot && rule.ForbidDotImports:
+					violations = append(violations, newViolation(RuleTypeImportStyle, spec.Position, pkgPath, spec.Path,
+						"Package %q dot-imports %q, which is not allowed",
+						pkgPath, spec.Path,
+					).withSeverity(rule.Severity))
+				case spec.Kind == ImportBlank && rule.ForbidBlankImports && !rule.allowsBlankImports(pkg):
+					violations = append(violations, newViolation(RuleTypeImportStyle, spec.Position, pkgPath, spec.Path,
+						"Package %q blank-imports %q, which is not allowed outside of the allowed packages",
+						pkgPath, spec.Path,
+					).withSeverity(rule.Severity))
+				}
+			}
+		}
+	}
+
+	return violations
+}
+
+// ValidateImportStyle validates that packages import other packages in the allowed styles
+// Rules with SeverityWarning are reported but don't make the validation fail.
+func (a *Architecture) ValidateImportStyle(rules []*ImportStyleRule) (bool, []string) {
+	valid, violations := a.ValidateImportStyleDetailed(rules)
+	return valid, violationStrings(violations)
+}
+
+// ValidateImportStyleDetailed validates that packages import other packages in the allowed
+// styles and returns structured violations
+func (a *Architecture) ValidateImportStyleDetailed(rules []*ImportStyleRule) (bool, []Violation) {
+	violations := a.checkImportStyle(rules)
+	return !HasErrors(violations), violations
+}
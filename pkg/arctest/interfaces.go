@@ -2,15 +2,25 @@ package arctest
 
 import (
 	"fmt"
+	"go/token"
+	"path"
 	"regexp"
+	"sort"
+	"strings"
 )
 
 // InterfaceImplementationRule represents a rule that structs must implement interfaces
 type InterfaceImplementationRule struct {
-	StructPattern         string // regex pattern for struct names
-	InterfacePattern      string // regex pattern for interface names
+	StructPattern         string   // regex pattern for struct names
+	InterfacePattern      string   // regex pattern for interface names
+	PackagePattern        string   // optional regex pattern for the package paths of structs
+	InterfacePackage      string   // optional path of the package the interfaces must be declared in
+	Layer                 *Layer   // optional layer whose structs are checked
+	ExportedOnly          bool     // if true, unexported structs are skipped
+	Severity              Severity // severity of violations, SeverityError if empty
 	structPatternRegex    *regexp.Regexp
 	interfacePatternRegex *regexp.Regexp
+	packagePatternRegex   *regexp.Regexp
 }
 
 // NewInterfaceImplementationRule creates a new interface implementation rule
@@ -33,61 +43,397 @@ func NewInterfaceImplementationRule(structPattern, interfacePattern string) (*In
 	}, nil
 }
 
-// CheckInterfaceImplementation checks if a struct implements an interface
-func CheckInterfaceImplementation(s *Struct, i *Interface) bool {
-	// If the interface has no methods, then any struct implements it
-	if len(i.Methods) == 0 {
+// InPackages restricts the rule to structs whose package path matches the pattern. The
+// pattern is matched against both the package path relative to the module root and the
+// full import path, so "^infrastructure/" and "^.*/infrastructure/" both work.
+func (r *InterfaceImplementationRule) InPackages(packagePattern string) (*InterfaceImplementationRule, error) {
+	packageRegex, err := regexp.Compile(packagePattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid package pattern: %w", err)
+	}
+
+	r.PackagePattern = packagePattern
+	r.packagePatternRegex = packageRegex
+	return r, nil
+}
+
+// FromPackage restricts the rule to interfaces declared in the package with the given path
+// or import path. Method signatures are then compared by the packages their types are
+// declared in rather than by package names, so that a struct implementing a similarly
+// shaped interface of another package doesn't count as an implementation.
+func (r *InterfaceImplementationRule) FromPackage(pkgPath string) *InterfaceImplementationRule {
+	r.InterfacePackage = pkgPath
+	return r
+}
+
+// matchesStruct checks if a struct is subject to the rule
+func (r *InterfaceImplementationRule) matchesStruct(a *Architecture, s *Struct) bool {
+	if !r.structPatternRegex.MatchString(a.ruleTypeName(s.Pkg, s.Name)) {
+		return false
+	}
+	if r.ExportedOnly && !token.IsExported(s.Name) {
+		return false
+	}
+	if r.Layer != nil && !r.Layer.Contains(s.Pkg.Path) {
+		return false
+	}
+	if r.packagePatternRegex == nil {
 		return true
 	}
+	return r.packagePatternRegex.MatchString(s.Pkg.Path) || r.packagePatternRegex.MatchString(a.ImportPath(s.Pkg.Path))
+}
+
+// interfaceRuleMatches counts the structs an interface implementation rule applies to
+func (a *Architecture) interfaceRuleMatches(rule *InterfaceImplementationRule) int {
+	count := 0
+	for _, pkg := range a.Packages {
+		for _, s := range pkg.Structs {
+			if rule.matchesStruct(a, s) {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// ImplementationKind describes which form of a struct implements an interface
+type ImplementationKind int
+
+const (
+	// NotImplemented means neither T nor *T implements the interface
+	NotImplemented ImplementationKind = iota
+	// PointerImplements means only *T implements the interface, because some of
+	// the required methods have pointer receivers
+	PointerImplements
+	// ValueImplements means both T and *T implement the interface
+	ValueImplements
+)
+
+// String returns a readable name for the implementation kind
+func (k ImplementationKind) String() string {
+	switch k {
+	case PointerImplements:
+		return "pointer"
+	case ValueImplements:
+		return "value"
+	default:
+		return "none"
+	}
+}
+
+// CheckInterfaceImplementation checks if a struct implements an interface and returns the
+// names of the interface methods the struct is missing or declares with a different signature.
+// A struct counts as an implementation if its pointer type does, see CheckImplementationKind.
+func CheckInterfaceImplementation(s *Struct, i *Interface) (bool, []string) {
+	_, unsatisfied := matchInterfaceMethods(s, i)
+	return len(unsatisfied) == 0, unsatisfied
+}
+
+// CheckExactInterfaceImplementation checks if a struct implements an interface like
+// CheckInterfaceImplementation, but compares the types of method signatures by the path of
+// the package declaring them. A parameter of type v1/domain.User then doesn't match one
+// of type v2/domain.User, although both are domain.User by name.
+func CheckExactInterfaceImplementation(s *Struct, i *Interface) (bool, []string) {
+	_, unsatisfied := matchMethodSet(s, i, true, true)
+	return len(unsatisfied) == 0, unsatisfied
+}
+
+// CheckImplementationKind checks whether the value type T, only the pointer type *T, or
+// neither form of a struct implements an interface
+func CheckImplementationKind(s *Struct, i *Interface) ImplementationKind {
+	if _, missing := matchInterfaceMethods(s, i); len(missing) > 0 {
+		return NotImplemented
+	}
+
+	// The method set of T only contains methods declared with a value receiver
+	if _, missing := matchMethodSet(s, i, false, false); len(missing) > 0 {
+		return PointerImplements
+	}
+	return ValueImplements
+}
+
+// describeUnsatisfiedMethods annotates each unsatisfied interface method with whether the
+// struct lacks it entirely or declares it with a different signature
+func describeUnsatisfiedMethods(s *Struct, unsatisfied []string) []string {
+	descriptions := make([]string, 0, len(unsatisfied))
+	for _, name := range unsatisfied {
+		reason := "missing"
+		for _, m := range methodSet(s, true) {
+			if m.method.Name == name {
+				reason = "signature mismatch"
+				break
+			}
+		}
+		descriptions = append(descriptions, fmt.Sprintf("%s (%s)", name, reason))
+	}
+	return descriptions
+}
+
+// matchInterfaceMethods compares the methods of a struct against an interface and
+// returns the number of interface methods the struct provides and the names of those it lacks
+func matchInterfaceMethods(s *Struct, i *Interface) (int, []string) {
+	return matchMethodSet(s, i, true, false)
+}
+
+// matchMethodSet compares the method set of *T (if includePointer is set) or T against
+// an interface and returns the number of matched methods and the names of missing ones.
+// If exact is set, named types in signatures are compared by the path of their package.
+func matchMethodSet(s *Struct, i *Interface, includePointer, exact bool) (int, []string) {
+	matched := 0
+	missing := []string{}
+	methods := methodSet(s, includePointer)
 
 	// Check if the struct has all the methods required by the interface
 	for _, iMethod := range i.Methods {
 		found := false
-		for _, sMethod := range s.Methods {
-			if sMethod.Name == iMethod.Name {
+		for _, sMethod := range methods {
+			if sMethod.method.Name == iMethod.Name {
 				// Check if the method signatures match
-				if methodSignaturesMatch(sMethod, iMethod) {
+				if methodSignaturesMatch(sMethod.method, sMethod.pkg, iMethod, i.Pkg, exact) {
 					found = true
 					break
 				}
 			}
 		}
-		if !found {
-			return false
+		if found {
+			matched++
+		} else {
+			missing = append(missing, iMethod.Name)
 		}
 	}
 
-	return true
+	return matched, missing
+}
+
+// methodRef is a method in the method set of a struct together with the package declaring it,
+// which differs from the struct's package for methods promoted from embedded types
+type methodRef struct {
+	method *Method
+	pkg    *Package
+}
+
+// methodSet returns the method set of *T if includePointer is set, or of T otherwise,
+// including methods promoted from embedded structs and interfaces within the architecture
+func methodSet(s *Struct, includePointer bool) []methodRef {
+	return collectMethodSet(s, includePointer, map[*Struct]bool{})
+}
+
+// collectMethodSet collects the method set of a struct, skipping structs already visited
+// to guard against embedding cycles
+func collectMethodSet(s *Struct, includePointer bool, visited map[*Struct]bool) []methodRef {
+	visited[s] = true
+
+	methods := []methodRef{}
+	seen := map[string]bool{}
+	for _, m := range s.Methods {
+		if m.PointerReceiver && !includePointer {
+			continue
+		}
+		methods = append(methods, methodRef{method: m, pkg: s.Pkg})
+		seen[m.Name] = true
+	}
+
+	// Methods declared on the struct shadow promoted methods of the same name
+	for _, embed := range s.Embeds {
+		embeddedPointer := strings.HasPrefix(embed, "*")
+		embeddedStruct, embeddedInterface := resolveEmbeddedType(s.Pkg, strings.TrimPrefix(embed, "*"))
+
+		promoted := []methodRef{}
+		switch {
+		case embeddedStruct != nil && !visited[embeddedStruct]:
+			// Embedding *E promotes the methods of *E even into the method set of T
+			promoted = collectMethodSet(embeddedStruct, includePointer || embeddedPointer, visited)
+		case embeddedInterface != nil:
+			for _, m := range embeddedInterface.Methods {
+				promoted = append(promoted, methodRef{method: m, pkg: embeddedInterface.Pkg})
+			}
+		}
+
+		for _, m := range promoted {
+			if !seen[m.method.Name] {
+				methods = append(methods, m)
+				seen[m.method.Name] = true
+			}
+		}
+	}
+
+	return methods
 }
 
-// methodSignaturesMatch checks if two methods have matching signatures
-// This is a simplified check that only ensures the method names match
-// In a real implementation, you would also check parameter types and return types
-func methodSignaturesMatch(m1, m2 *Method) bool {
+// resolveEmbeddedType looks up the struct or interface an embedded type name refers to,
+// searching the declaring package for plain names and imported packages for qualified ones
+func resolveEmbeddedType(pkg *Package, typeName string) (*Struct, *Interface) {
+	if pkg == nil {
+		return nil, nil
+	}
+
+	target := pkg
+	if dot := strings.Index(typeName, "."); dot >= 0 {
+		target = nil
+		if importPath, ok := pkg.ImportedPkgs[typeName[:dot]]; ok && pkg.arch != nil {
+			if pkgPath, ok := pkg.arch.ResolveImport(importPath); ok {
+				target = pkg.arch.Packages[pkgPath]
+			}
+		}
+		typeName = typeName[dot+1:]
+	}
+	if target == nil {
+		return nil, nil
+	}
+
+	return target.Structs[typeName], target.Interfaces[typeName]
+}
+
+// methodSignaturesMatch checks if two methods have matching signatures.
+// Parameter and result types are compared one by one after qualifying them with the
+// package that declares each method, by its path if exact is set. With LooseMatching,
+// parameters and results are only compared by count unless exact is set.
+func methodSignaturesMatch(m1 *Method, p1 *Package, m2 *Method, p2 *Package, exact bool) bool {
 	if m1.Name != m2.Name {
 		return false
 	}
 
-	// For simplicity, we're just checking if the parameter count matches
-	// In a real implementation, you would check parameter types as well
 	if len(m1.Params) != len(m2.Params) {
 		return false
 	}
 
-	// Check if both have return values or neither does
-	if (m1.ReturnType == "") != (m2.ReturnType == "") {
+	// Compare parameter and result types one by one, unless only their count should be checked
+	qualify := qualifyType
+	if exact {
+		qualify = qualifyTypeExact
+	}
+	loose := !exact && p1 != nil && p1.arch != nil && p1.arch.LooseMatching
+	if !loose {
+		for idx := range m1.Params {
+			if qualify(m1.Params[idx].Type, p1) != qualify(m2.Params[idx].Type, p2) {
+				return false
+			}
+		}
+	}
+
+	// Check that both methods return the same types in the same order
+	if len(m1.Returns) != len(m2.Returns) {
 		return false
 	}
+	if loose {
+		return true
+	}
+	for idx := range m1.Returns {
+		if qualify(m1.Returns[idx].Type, p1) != qualify(m2.Returns[idx].Type, p2) {
+			return false
+		}
+	}
 
 	return true
 }
 
+// typeIdentRegex matches identifiers in a rendered type, optionally qualified by a package
+var typeIdentRegex = regexp.MustCompile(`[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?`)
+
+// qualifyType rewrites a type declared in the given package so that every named type
+// is qualified by the name of the package it comes from, e.g. *User in package domain
+// and *domain.User in package infrastructure both become *domain.User
+func qualifyType(typeName string, pkg *Package) string {
+	return qualifyTypeWith(typeName, pkg, false)
+}
+
+// qualifyTypeExact rewrites a type declared in the given package like qualifyType, but
+// qualifies named types by the path of their package, so that types of different
+// packages sharing a name, such as v1/domain.User and v2/domain.User, stay apart
+func qualifyTypeExact(typeName string, pkg *Package) string {
+	return qualifyTypeWith(typeName, pkg, true)
+}
+
+// qualifyTypeWith qualifies every named type of a rendered type by its package's name,
+// or by its package's path if exact is set
+func qualifyTypeWith(typeName string, pkg *Package, exact bool) string {
+	if pkg == nil {
+		return typeName
+	}
+
+	var b strings.Builder
+	last := 0
+	for _, loc := range typeIdentRegex.FindAllStringIndex(typeName, -1) {
+		b.WriteString(typeName[last:loc[0]])
+		b.WriteString(qualifyIdent(typeName[loc[0]:loc[1]], typeName[loc[1]:], pkg, exact))
+		last = loc[1]
+	}
+	b.WriteString(typeName[last:])
+	return b.String()
+}
+
+// qualifyIdent qualifies a single identifier of a rendered type, given the rest of the type
+// following it. Method names of anonymous interfaces, which are followed by their
+// parameter list, are left as they are.
+func qualifyIdent(ident, rest string, pkg *Package, exact bool) string {
+	if strings.HasPrefix(rest, "(") {
+		return ident
+	}
+
+	if dot := strings.Index(ident, "."); dot >= 0 {
+		// Resolve import aliases to the imported package's name or import path
+		importPath, ok := pkg.ImportedPkgs[ident[:dot]]
+		switch {
+		case !ok:
+			return ident
+		case exact:
+			// Packages of the architecture are identified by their path, so that imports
+			// match local references even without a module path
+			if pkg.arch != nil {
+				if pkgPath, ok := pkg.arch.ResolveImport(importPath); ok {
+					return pkgPath + ident[dot:]
+				}
+			}
+			return importPath + ident[dot:]
+		default:
+			return path.Base(importPath) + ident[dot:]
+		}
+	}
+
+	if isPrimitiveType(ident) || isTypeKeyword(ident) {
+		return ident
+	}
+	if exact {
+		return pkg.Path + "." + ident
+	}
+	return pkg.Name + "." + ident
+}
+
+// isTypeKeyword checks if an identifier is a keyword or predeclared name that can
+// appear inside a rendered type
+func isTypeKeyword(ident string) bool {
+	switch ident {
+	case "map", "chan", "func", "interface", "struct", "any", "comparable":
+		return true
+	}
+	return false
+}
+
 // CheckStructImplementsInterfaces checks all structs against the provided interface implementation rules
 func (a *Architecture) CheckStructImplementsInterfaces(rules []*InterfaceImplementationRule) ([]string, error) {
-	violations := []string{}
+	return violationStrings(a.checkStructImplementsInterfaces(rules)), nil
+}
+
+// checkStructImplementsInterfaces checks all structs against the provided interface implementation rules
+func (a *Architecture) checkStructImplementsInterfaces(rules []*InterfaceImplementationRule) []Violation {
+	violations := []Violation{}
 
 	// For each rule
 	for _, rule := range rules {
+		// Interfaces of a specific package are compared by package identity
+		var interfacePkg string
+		exact := rule.InterfacePackage != ""
+		if exact {
+			pkgPath, ok := a.ResolveImport(rule.InterfacePackage)
+			if !ok {
+				violations = append(violations, newViolation(RuleTypeInterfaceImplementation, token.Position{}, "", "",
+					"Package %q of the interfaces matching %q not found", rule.InterfacePackage, rule.InterfacePattern,
+				).withSeverity(rule.Severity))
+				continue
+			}
+			interfacePkg = pkgPath
+		}
+
 		// Keep track of matching structs and interfaces
 		matchingStructs := []*Struct{}
 		matchingInterfaces := []*Interface{}
@@ -95,13 +441,16 @@ func (a *Architecture) CheckStructImplementsInterfaces(rules []*InterfaceImpleme
 		// Find all structs and interfaces that match the pattern
 		for _, pkg := range a.Packages {
 			for _, s := range pkg.Structs {
-				if rule.structPatternRegex.MatchString(s.Name) {
+				if rule.matchesStruct(a, s) {
 					matchingStructs = append(matchingStructs, s)
 				}
 			}
 
+			if exact && pkg.Path != interfacePkg {
+				continue
+			}
 			for _, i := range pkg.Interfaces {
-				if rule.interfacePatternRegex.MatchString(i.Name) {
+				if rule.interfacePatternRegex.MatchString(a.ruleTypeName(pkg, i.Name)) {
 					matchingInterfaces = append(matchingInterfaces, i)
 				}
 			}
@@ -110,23 +459,46 @@ func (a *Architecture) CheckStructImplementsInterfaces(rules []*InterfaceImpleme
 		// For each matching struct, check if it implements at least one matching interface
 		for _, s := range matchingStructs {
 			implementsAny := false
+			var closest *Interface
+			closestMatched := -1
+			var closestUnsatisfied []string
 			for _, i := range matchingInterfaces {
-				if CheckInterfaceImplementation(s, i) {
+				matched, unsatisfied := matchMethodSet(s, i, true, exact)
+				if len(unsatisfied) == 0 {
 					implementsAny = true
 					break
 				}
+
+				// Remember the interface the struct comes closest to implementing
+				if matched > closestMatched || (matched == closestMatched && interfaceLess(i, closest)) {
+					closest = i
+					closestMatched = matched
+					closestUnsatisfied = unsatisfied
+				}
 			}
 
 			if !implementsAny && len(matchingInterfaces) > 0 {
-				violations = append(violations, fmt.Sprintf(
-					"Struct %q in package %q does not implement any interface matching %q",
-					s.Name, s.Pkg.Path, rule.InterfacePattern,
-				))
+				violations = append(violations, newViolation(RuleTypeInterfaceImplementation, s.Position, s.Pkg.Path, closest.Pkg.Path,
+					"Struct %q in package %q does not implement any interface matching %q; closest is %q in package %q, which also requires %s",
+					s.Name, s.Pkg.Path, rule.InterfacePattern, closest.Name, closest.Pkg.Path,
+					strings.Join(describeUnsatisfiedMethods(s, closestUnsatisfied), ", "),
+				).withSeverity(rule.Severity))
 			}
 		}
 	}
 
-	return violations, nil
+	return violations
+}
+
+// interfaceLess orders interfaces by package path and name, treating nil as the largest value
+func interfaceLess(i, other *Interface) bool {
+	if other == nil {
+		return true
+	}
+	if i.Pkg.Path != other.Pkg.Path {
+		return i.Pkg.Path < other.Pkg.Path
+	}
+	return i.Name < other.Name
 }
 
 // StructsImplementInterfaces creates a rule that structs matching a pattern must implement interfaces matching a pattern
@@ -135,9 +507,17 @@ func (a *Architecture) StructsImplementInterfaces(structPattern, interfacePatter
 }
 
 // ValidateInterfaceImplementations validates that structs implement interfaces according to rules
+// Rules with SeverityWarning are reported but don't make the validation fail.
 func (a *Architecture) ValidateInterfaceImplementations(rules []*InterfaceImplementationRule) (bool, []string) {
-	violations, _ := a.CheckStructImplementsInterfaces(rules)
-	return len(violations) == 0, violations
+	valid, violations := a.ValidateInterfaceImplementationsDetailed(rules)
+	return valid, violationStrings(violations)
+}
+
+// ValidateInterfaceImplementationsDetailed validates that structs implement interfaces
+// according to rules and returns structured violations
+func (a *Architecture) ValidateInterfaceImplementationsDetailed(rules []*InterfaceImplementationRule) (bool, []Violation) {
+	violations := a.checkStructImplementsInterfaces(rules)
+	return !HasErrors(violations), violations
 }
 
 // FindAllImplementations finds all structs that implement a given interface
@@ -158,7 +538,7 @@ func (a *Architecture) FindAllImplementations(interfaceName, interfacePkgPath st
 
 	for _, pkg := range a.Packages {
 		for _, s := range pkg.Structs {
-			if CheckInterfaceImplementation(s, iface) {
+			if implements, _ := CheckInterfaceImplementation(s, iface); implements {
 				implementations = append(implementations, s)
 			}
 		}
@@ -166,3 +546,324 @@ func (a *Architecture) FindAllImplementations(interfaceName, interfacePkgPath st
 
 	return implementations, nil
 }
+
+// StructsMustImplement reports structs matching the pattern that don't implement the given
+// interface, together with the methods they lack. Unlike StructsImplementInterfaces, which
+// accepts any interface matching a pattern, every struct is checked against this one interface.
+func (a *Architecture) StructsMustImplement(structPattern, interfaceName, interfacePkgPath string) ([]string, error) {
+	violations, err := a.StructsMustImplementDetailed(structPattern, interfaceName, interfacePkgPath)
+	if err != nil {
+		return nil, err
+	}
+	return violationStrings(violations), nil
+}
+
+// StructsMustImplementDetailed reports structs matching the pattern that don't implement
+// the given interface and returns structured violations
+func (a *Architecture) StructsMustImplementDetailed(structPattern, interfaceName, interfacePkgPath string) ([]Violation, error) {
+	structRegex, err := regexp.Compile(structPattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid struct pattern: %w", err)
+	}
+
+	pkg := a.GetPackage(interfacePkgPath)
+	if pkg == nil {
+		return nil, fmt.Errorf("package %q not found", interfacePkgPath)
+	}
+
+	iface, found := pkg.Interfaces[interfaceName]
+	if !found {
+		return nil, fmt.Errorf("interface %q not found in package %q", interfaceName, interfacePkgPath)
+	}
+
+	structs := []*Struct{}
+	for _, p := range a.Packages {
+		for _, s := range p.Structs {
+			if structRegex.MatchString(a.ruleTypeName(p, s.Name)) {
+				structs = append(structs, s)
+			}
+		}
+	}
+	sort.Slice(structs, func(x, y int) bool {
+		if structs[x].Pkg.Path != structs[y].Pkg.Path {
+			return structs[x].Pkg.Path < structs[y].Pkg.Path
+		}
+		return structs[x].Name < structs[y].Name
+	})
+
+	violations := []Violation{}
+	for _, s := range structs {
+		implements, unsatisfied := CheckInterfaceImplementation(s, iface)
+		if implements {
+			continue
+		}
+		violations = append(violations, newViolation(RuleTypeInterfaceImplementation, s.Position, s.Pkg.Path, iface.Pkg.Path,
+			"Struct %q in package %q does not implement %q of package %q, which requires %s",
+			s.Name, s.Pkg.Path, iface.Name, iface.Pkg.Path,
+			strings.Join(describeUnsatisfiedMethods(s, unsatisfied), ", "),
+		))
+	}
+
+	return violations, nil
+}
+
+// InterfaceMustBeImplemented reports interfaces matching the pattern that are implemented
+// by fewer than minCount of the parsed structs, e.g. unused ports of a hexagonal architecture.
+// With QualifiedNames set, the pattern matches names such as "domain.OrderRepository".
+func (a *Architecture) InterfaceMustBeImplemented(interfacePattern string, minCount int) ([]string, error) {
+	violations, err := a.checkInterfaceMustBeImplemented(interfacePattern, minCount)
+	if err != nil {
+		return nil, err
+	}
+	return violationStrings(violations), nil
+}
+
+// InterfaceMustBeImplementedDetailed reports interfaces matching the pattern that are
+// implemented by fewer than minCount structs and returns structured violations
+func (a *Architecture) InterfaceMustBeImplementedDetailed(interfacePattern string, minCount int) ([]Violation, error) {
+	return a.checkInterfaceMustBeImplemented(interfacePattern, minCount)
+}
+
+// checkInterfaceMustBeImplemented counts the implementations of every matching interface
+func (a *Architecture) checkInterfaceMustBeImplemented(interfacePattern string, minCount int) ([]Violation, error) {
+	interfaceRegex, err := regexp.Compile(interfacePattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid interface pattern: %w", err)
+	}
+
+	violations := []Violation{}
+	for _, i := range a.sortedInterfaces() {
+		if !interfaceRegex.MatchString(a.ruleTypeName(i.Pkg, i.Name)) {
+			continue
+		}
+
+		count := len(a.implementationsOf(i))
+		if count < minCount {
+			violations = append(violations, newViolation(RuleTypeInterfaceImplementation, i.Position, i.Pkg.Path, "",
+				"Interface %q in package %q has %d implementation(s), but at least %d are required",
+				i.Name, i.Pkg.Path, count, minCount,
+			))
+		}
+	}
+
+	return violations, nil
+}
+
+// MustContainAllImplementationsOf reports structs implementing an interface matching the
+// pattern that are declared outside this layer, e.g. adapters of a port that don't live
+// in the infrastructure layer. Like InterfaceMustBeImplemented, the pattern matches
+// qualified names with QualifiedNames set.
+func (l *Layer) MustContainAllImplementationsOf(interfacePattern string) ([]string, error) {
+	violations, err := l.checkImplementationsContained(interfacePattern)
+	if err != nil {
+		return nil, err
+	}
+	return violationStrings(violations), nil
+}
+
+// MustContainAllImplementationsOfDetailed reports implementations of matching interfaces
+// outside this layer and returns structured violations
+func (l *Layer) MustContainAllImplementationsOfDetailed(interfacePattern string) ([]Violation, error) {
+	return l.checkImplementationsContained(interfacePattern)
+}
+
+// checkImplementationsContained flags implementations of matching interfaces outside the layer.
+// Interfaces without methods are skipped, since every struct implements them.
+func (l *Layer) checkImplementationsContained(interfacePattern string) ([]Violation, error) {
+	if l.arch == nil {
+		return nil, fmt.Errorf("layer %q is not associated with an architecture", l.Name)
+	}
+
+	interfaceRegex, err := regexp.Compile(interfacePattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid interface pattern: %w", err)
+	}
+
+	include := func(i *Interface) bool {
+		return interfaceRegex.MatchString(l.arch.ruleTypeName(i.Pkg, i.Name))
+	}
+	return l.arch.misplacedImplementations(include, l, func(s *Struct, i *Interface) Violation {
+		return newViolation(RuleTypeInterfaceImplementation, s.Position, s.Pkg.Path, i.Pkg.Path,
+			"Struct %q in package %q implements %q of package %q, but implementations must be in layer %q",
+			s.Name, s.Pkg.Path, i.Name, i.Pkg.Path, l.Name,
+		)
+	}), nil
+}
+
+// AssertDependencyInversion checks that every struct implementing an interface declared
+// in the interface layer lives in the implementation layer, e.g. that the adapters of the
+// ports in an inner layer are declared in an outer layer rather than next to the ports
+func (la *LayeredArchitecture) AssertDependencyInversion(interfaceLayerName, implementationLayerName string) ([]string, error) {
+	violations, err := la.checkDependencyInversion(interfaceLayerName, implementationLayerName)
+	if err != nil {
+		return nil, err
+	}
+	return violationStrings(violations), nil
+}
+
+// AssertDependencyInversionDetailed checks that implementations of the interface layer's
+// interfaces live in the implementation layer and returns structured violations
+func (la *LayeredArchitecture) AssertDependencyInversionDetailed(interfaceLayerName, implementationLayerName string) ([]Violation, error) {
+	return la.checkDependencyInversion(interfaceLayerName, implementationLayerName)
+}
+
+// checkDependencyInversion flags implementations of the interface layer's interfaces outside
+// the implementation layer. Interfaces without methods are skipped, since every struct
+// implements them.
+func (la *LayeredArchitecture) checkDependencyInversion(interfaceLayerName, implementationLayerName string) ([]Violation, error) {
+	if la.arch == nil {
+		return nil, fmt.Errorf("layered architecture is not associated with an architecture")
+	}
+
+	interfaceLayer := la.WhereLayer(interfaceLayerName)
+	if interfaceLayer == nil {
+		return nil, fmt.Errorf("interface layer %q not found", interfaceLayerName)
+	}
+
+	implementationLayer := la.WhereLayer(implementationLayerName)
+	if implementationLayer == nil {
+		return nil, fmt.Errorf("implementation layer %q not found", implementationLayerName)
+	}
+
+	include := func(i *Interface) bool {
+		return interfaceLayer.Contains(i.Pkg.Path)
+	}
+	return la.arch.misplacedImplementations(include, implementationLayer, func(s *Struct, i *Interface) Violation {
+		return newViolation(RuleTypeInterfaceImplementation, s.Position, s.Pkg.Path, i.Pkg.Path,
+			"Struct %q in package %q implements %q of layer %q, but implementations must be in layer %q",
+			s.Name, s.Pkg.Path, i.Name, interfaceLayer.Name, implementationLayer.Name,
+		)
+	}), nil
+}
+
+// misplacedImplementations reports every struct outside the layer that implements one of
+// the interfaces selected by include, using report to build the violation. Interfaces
+// without methods are skipped, since every struct implements them.
+func (a *Architecture) misplacedImplementations(include func(*Interface) bool, layer *Layer, report func(*Struct, *Interface) Violation) []Violation {
+	violations := []Violation{}
+	for _, i := range a.sortedInterfaces() {
+		if len(i.Methods) == 0 || !include(i) {
+			continue
+		}
+
+		for _, s := range a.implementationsOf(i) {
+			if layer.Contains(s.Pkg.Path) {
+				continue
+			}
+			violations = append(violations, report(s, i))
+		}
+	}
+	return violations
+}
+
+// sortedInterfaces returns all parsed interfaces ordered by package path and name
+func (a *Architecture) sortedInterfaces() []*Interface {
+	interfaces := []*Interface{}
+	for _, pkg := range a.Packages {
+		for _, i := range pkg.Interfaces {
+			interfaces = append(interfaces, i)
+		}
+	}
+	sort.Slice(interfaces, func(x, y int) bool {
+		return interfaceLess(interfaces[x], interfaces[y])
+	})
+	return interfaces
+}
+
+// implementationsOf returns all parsed structs implementing the interface, ordered by
+// package path and name
+func (a *Architecture) implementationsOf(i *Interface) []*Struct {
+	implementations := []*Struct{}
+	for _, pkg := range a.Packages {
+		for _, s := range pkg.Structs {
+			if implements, _ := CheckInterfaceImplementation(s, i); implements {
+				implementations = append(implementations, s)
+			}
+		}
+	}
+	sort.Slice(implementations, func(x, y int) bool {
+		if implementations[x].Pkg.Path != implementations[y].Pkg.Path {
+			return implementations[x].Pkg.Path < implementations[y].Pkg.Path
+		}
+		return implementations[x].Name < implementations[y].Name
+	})
+	return implementations
+}
+
+// Implementation describes a struct implementing an interface and whether a value
+// of the struct can be used or only a pointer to it
+type Implementation struct {
+	Struct *Struct
+	Kind   ImplementationKind
+}
+
+// FindAllImplementationsWithReceivers finds all structs that implement a given interface,
+// reporting for each whether the value type or only the pointer type implements it
+func (a *Architecture) FindAllImplementationsWithReceivers(interfaceName, interfacePkgPath string) ([]Implementation, error) {
+	structs, err := a.FindAllImplementations(interfaceName, interfacePkgPath)
+	if err != nil {
+		return nil, err
+	}
+
+	iface := a.GetPackage(interfacePkgPath).Interfaces[interfaceName]
+	implementations := make([]Implementation, 0, len(structs))
+	for _, s := range structs {
+		implementations = append(implementations, Implementation{
+			Struct: s,
+			Kind:   CheckImplementationKind(s, iface),
+		})
+	}
+
+	return implementations, nil
+}
+
+// ImplMatch describes how closely a struct matches the method set of an interface
+type ImplMatch struct {
+	Struct         *Struct
+	MatchedMethods int      // number of interface methods the struct provides
+	MissingMethods []string // names of interface methods the struct lacks
+}
+
+// RankImplementations ranks all structs that provide at least one method of the given
+// interface by how many of its methods they match, best matches first.
+// It returns nil if the interface cannot be found.
+func (a *Architecture) RankImplementations(interfaceName, pkgPath string) []ImplMatch {
+	pkg := a.GetPackage(pkgPath)
+	if pkg == nil {
+		return nil
+	}
+
+	iface, found := pkg.Interfaces[interfaceName]
+	if !found {
+		return nil
+	}
+
+	matches := []ImplMatch{}
+	for _, p := range a.Packages {
+		for _, s := range p.Structs {
+			matched, missing := matchInterfaceMethods(s, iface)
+			if matched == 0 && len(missing) > 0 {
+				continue
+			}
+
+			matches = append(matches, ImplMatch{
+				Struct:         s,
+				MatchedMethods: matched,
+				MissingMethods: missing,
+			})
+		}
+	}
+
+	// Sort by match quality, falling back to package path and name for a stable order
+	sort.Slice(matches, func(x, y int) bool {
+		if matches[x].MatchedMethods != matches[y].MatchedMethods {
+			return matches[x].MatchedMethods > matches[y].MatchedMethods
+		}
+		if matches[x].Struct.Pkg.Path != matches[y].Struct.Pkg.Path {
+			return matches[x].Struct.Pkg.Path < matches[y].Struct.Pkg.Path
+		}
+		return matches[x].Struct.Name < matches[y].Struct.Name
+	})
+
+	return matches
+}
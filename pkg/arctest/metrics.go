@@ -0,0 +1,136 @@
+package arctest
+
+import (
+	"go/token"
+	"sort"
+)
+
+// CheckCoupling reports packages that are imported by more than maxDependents packages
+// (afferent coupling) or that import more than maxDependencies packages (efferent
+// coupling) of the architecture. A negative threshold disables the corresponding check.
+func (a *Architecture) CheckCoupling(maxDependents, maxDependencies int) []string {
+	return violationStrings(a.checkCoupling(maxDependents, maxDependencies))
+}
+
+// CheckCouplingDetailed reports packages exceeding the coupling thresholds and returns
+// structured violations
+func (a *Architecture) CheckCouplingDetailed(maxDependents, maxDependencies int) []Violation {
+	return a.checkCoupling(maxDependents, maxDependencies)
+}
+
+// checkCoupling flags packages with too many dependents or dependencies. Since they
+// concern a package as a whole, the violations have no position.
+func (a *Architecture) checkCoupling(maxDependents, maxDependencies int) []Violation {
+	violations := []Violation{}
+	dependencies := a.internalDependencies()
+	dependents := a.internalDependents()
+
+	pkgPaths := make([]string, 0, len(dependencies))
+	for pkgPath := range dependencies {
+		pkgPaths = append(pkgPaths, pkgPath)
+	}
+	sort.Strings(pkgPaths)
+
+	for _, pkgPath := range pkgPaths {
+		if maxDependents >= 0 && len(dependents[pkgPath]) > maxDependents {
+			violations = append(violations, newViolation(RuleTypeCoupling, token.Position{}, pkgPath, "",
+				"Package %q is imported by %d packages, more than the maximum of %d: %v",
+				pkgPath, len(dependents[pkgPath]), maxDependents, dependents[pkgPath],
+			))
+		}
+		if maxDependencies >= 0 && len(dependencies[pkgPath]) > maxDependencies {
+			violations = append(violations, newViolation(RuleTypeCoupling, token.Position{}, pkgPath, "",
+				"Package %q imports %d packages, more than the maximum of %d: %v",
+				pkgPath, len(dependencies[pkgPath]), maxDependencies, dependencies[pkgPath],
+			))
+		}
+	}
+
+	return violations
+}
+
+// PackageMetrics describes how a package is coupled to the rest of the architecture
+type PackageMetrics struct {
+	Afferent     int     // number of packages that import the package (Ca)
+	Efferent     int     // number of packages the package imports (Ce)
+	Instability  float64 // Ce / (Ca + Ce), from 0 (maximally stable) to 1 (maximally unstable)
+	Abstractness float64 // ratio of interfaces to all types declared in the package
+}
+
+// Metrics computes the coupling metrics of every package of the architecture. Only
+// imports of other packages of the architecture are taken into account. Packages
+// without any dependencies or dependents have an instability of 0.
+func (a *Architecture) Metrics() map[string]PackageMetrics {
+	dependencies := a.internalDependencies()
+	dependents := a.internalDependents()
+
+	metrics := make(map[string]PackageMetrics, len(dependencies))
+	for pkgPath, pkg := range a.Packages {
+		m := PackageMetrics{
+			Afferent: len(dependents[pkgPath]),
+			Efferent: len(dependencies[pkgPath]),
+		}
+		if m.Afferent+m.Efferent > 0 {
+			m.Instability = float64(m.Efferent) / float64(m.Afferent+m.Efferent)
+		}
+
+		types := len(pkg.Structs) + len(pkg.Interfaces) + len(pkg.NamedTypes)
+		if types > 0 {
+			m.Abstractness = float64(len(pkg.Interfaces)) / float64(types)
+		}
+
+		metrics[pkgPath] = m
+	}
+
+	return metrics
+}
+
+// AssertStableDependencies checks the stable dependencies principle: a package should
+// only depend on packages that are at least as stable as itself, that is, whose
+// instability is lower than or equal to its own
+func (a *Architecture) AssertStableDependencies() (bool, []string) {
+	valid, violations := a.AssertStableDependenciesDetailed()
+	return valid, violationStrings(violations)
+}
+
+// AssertStableDependenciesDetailed checks the stable dependencies principle and returns
+// structured violations located at the offending imports
+func (a *Architecture) AssertStableDependenciesDetailed() (bool, []Violation) {
+	violations := []Violation{}
+	metrics := a.Metrics()
+	dependencies := a.internalDependencies()
+
+	pkgPaths := make([]string, 0, len(dependencies))
+	for pkgPath := range dependencies {
+		pkgPaths = append(pkgPaths, pkgPath)
+	}
+	sort.Strings(pkgPaths)
+
+	for _, pkgPath := range pkgPaths {
+		for _, target := range dependencies[pkgPath] {
+			if metrics[target].Instability > metrics[pkgPath].Instability {
+				violations = append(violations, newViolation(RuleTypeCoupling, a.importPositionOf(pkgPath, target), pkgPath, target,
+					"Package %q (instability %.2f) depends on the less stable package %q (instability %.2f)",
+					pkgPath, metrics[pkgPath].Instability, target, metrics[target].Instability,
+				))
+			}
+		}
+	}
+
+	return !HasErrors(violations), violations
+}
+
+// importPositionOf returns the position of the first import of the package that resolves
+// to the target package, or an empty position if there is none
+func (a *Architecture) importPositionOf(pkgPath, target string) token.Position {
+	pkg := a.Packages[pkgPath]
+	if pkg == nil {
+		return token.Position{}
+	}
+	for idx, importPath := range pkg.Imports {
+		if resolved, ok := a.ResolveImport(importPath); ok && resolved == target {
+			return pkg.importPosition(idx)
+		}
+	}
+	return token.Position{}
+}
@@ -0,0 +1,86 @@
+package arctest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"sort"
+)
+
+// modelVersion identifies the schema written by DumpModel. It is increased whenever
+// the schema changes in a way that older readers can't handle.
+const modelVersion = 1
+
+// model is the serialized form of an architecture
+type model struct {
+	Version      int
+	ModulePath   string
+	ImportBase   string // import path corresponding to the analyzed directory
+	IncludeTests bool
+	Packages     []*Package // ordered by path
+}
+
+// DumpModel writes the parsed packages with their imports, types, functions and calls as
+// JSON, which allows external tools to consume them and LoadModel to restore them
+func (a *Architecture) DumpModel(w io.Writer) error {
+	m := model{
+		Version:      modelVersion,
+		ModulePath:   a.ModulePath,
+		ImportBase:   a.importBase,
+		IncludeTests: a.IncludeTests,
+		Packages:     make([]*Package, 0, len(a.Packages)),
+	}
+	for _, p := range a.Packages {
+		m.Packages = append(m.Packages, p)
+	}
+	sort.Slice(m.Packages, func(i, j int) bool {
+		return m.Packages[i].Path < m.Packages[j].Path
+	})
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(m); err != nil {
+		return fmt.Errorf("failed to write model: %w", err)
+	}
+	return nil
+}
+
+// LoadModel creates an Architecture from a model written by DumpModel, so that rules can
+// be checked without parsing the source code again. The returned architecture has no
+// source tree, so ParsePackages fails on it.
+func LoadModel(r io.Reader) (*Architecture, error) {
+	var m model
+	if err := json.NewDecoder(r).Decode(&m); err != nil {
+		return nil, fmt.Errorf("failed to read model: %w", err)
+	}
+	if m.Version != modelVersion {
+		return nil, fmt.Errorf("unsupported model version %d", m.Version)
+	}
+
+	a, err := newArchitecture(emptyFS{}, ".", nil)
+	if err != nil {
+		return nil, err
+	}
+	a.ModulePath = m.ModulePath
+	a.importBase = m.ImportBase
+	a.IncludeTests = m.IncludeTests
+
+	for _, p := range m.Packages {
+		if p == nil || p.Path == "" {
+			return nil, fmt.Errorf("invalid model: package without path")
+		}
+		a.adoptPackage(p)
+		a.Packages[p.Path] = p
+	}
+
+	return a, nil
+}
+
+// emptyFS is a file system without any files, used by architectures without a source tree
+type emptyFS struct{}
+
+// Open implements fs.FS
+func (emptyFS) Open(name string) (fs.File, error) {
+	return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+}
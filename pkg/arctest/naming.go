@@ -0,0 +1,153 @@
+package arctest
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+)
+
+// interfacePrefixRegex matches C#/Java-style interface names such as IUserRepository
+var interfacePrefixRegex = regexp.MustCompile(`^I[A-Z]`)
+
+// InterfacesMustNotBePrefixedWithI checks that no interface name starts with an "I" prefix,
+// following the Go convention of naming interfaces after their behavior
+func (a *Architecture) InterfacesMustNotBePrefixedWithI() (bool, []string) {
+	violations := []string{}
+
+	for _, i := range a.sortedInterfaces() {
+		if interfacePrefixRegex.MatchString(i.Name) {
+			violations = append(violations, fmt.Sprintf(
+				"Interface %q in package %q is prefixed with \"I\", which is not idiomatic Go",
+				i.Name, i.Pkg.Path,
+			))
+		}
+	}
+
+	return len(violations) == 0, violations
+}
+
+// TypeKind identifies the kind of type a naming rule applies to
+type TypeKind string
+
+const (
+	// TypeKindStruct makes a naming rule apply to struct types
+	TypeKindStruct TypeKind = "struct"
+	// TypeKindInterface makes a naming rule apply to interface types
+	TypeKindInterface TypeKind = "interface"
+	// TypeKindNamedType makes a naming rule apply to other named types and type aliases
+	TypeKindNamedType TypeKind = "named"
+)
+
+// NamingRule represents a rule that the names of types in a layer must match a pattern
+type NamingRule struct {
+	Layer            *Layer   // layer whose packages are checked
+	Kind             TypeKind // kind of types the rule applies to
+	NamePattern      string   // regex pattern type names must match
+	Severity         Severity // severity of violations, SeverityError if empty
+	namePatternRegex *regexp.Regexp
+}
+
+// newNamingRule creates a naming rule for the given layer and kind of types
+func newNamingRule(layer *Layer, kind TypeKind, namePattern string) (*NamingRule, error) {
+	nameRegex, err := regexp.Compile(namePattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid name pattern: %w", err)
+	}
+
+	return &NamingRule{
+		Layer:            layer,
+		Kind:             kind,
+		NamePattern:      namePattern,
+		namePatternRegex: nameRegex,
+	}, nil
+}
+
+// EnforceStructNaming creates a rule that the names of all structs in this layer
+// must match a pattern, e.g. "(Repository|Adapter)$"
+func (l *Layer) EnforceStructNaming(namePattern string) (*NamingRule, error) {
+	return newNamingRule(l, TypeKindStruct, namePattern)
+}
+
+// EnforceInterfaceNaming creates a rule that the names of all interfaces in this layer
+// must match a pattern, e.g. "Interface$"
+func (l *Layer) EnforceInterfaceNaming(namePattern string) (*NamingRule, error) {
+	return newNamingRule(l, TypeKindInterface, namePattern)
+}
+
+// EnforceNamedTypeNaming creates a rule that the names of all types in this layer that are
+// neither structs nor interfaces, including type aliases, must match a pattern, e.g. "ID$"
+func (l *Layer) EnforceNamedTypeNaming(namePattern string) (*NamingRule, error) {
+	return newNamingRule(l, TypeKindNamedType, namePattern)
+}
+
+// checkNamingRules checks that type names in the layers of the rules match their patterns
+func (a *Architecture) checkNamingRules(rules []*NamingRule) []Violation {
+	violations := []Violation{}
+
+	for _, rule := range rules {
+		start := len(violations)
+		for pkgPath, pkg := range a.Packages {
+			if !rule.Layer.Contains(pkgPath) {
+				continue
+			}
+
+			switch rule.Kind {
+			case TypeKindStruct:
+				for name, s := range pkg.Structs {
+					if !rule.namePatternRegex.MatchString(name) {
+						violations = append(violations, newViolation(RuleTypeNaming, s.Position, pkgPath, "",
+							"Struct %q in package %q of layer %q does not match the naming pattern %q",
+							name, pkgPath, rule.Layer.Name, rule.NamePattern,
+						).withSeverity(rule.Severity))
+					}
+				}
+			case TypeKindInterface:
+				for name, i := range pkg.Interfaces {
+					if !rule.namePatternRegex.MatchString(name) {
+						violations = append(violations, newViolation(RuleTypeNaming, i.Position, pkgPath, "",
+							"Interface %q in package %q of layer %q does not match the naming pattern %q",
+							name, pkgPath, rule.Layer.Name, rule.NamePattern,
+						).withSeverity(rule.Severity))
+					}
+				}
+			case TypeKindNamedType:
+				for _, types := range []map[string]*NamedType{pkg.NamedTypes, pkg.TypeAliases} {
+					for name, t := range types {
+						if !rule.namePatternRegex.MatchString(name) {
+							violations = append(violations, newViolation(RuleTypeNaming, t.Position, pkgPath, "",
+								"Type %q in package %q of layer %q does not match the naming pattern %q",
+								name, pkgPath, rule.Layer.Name, rule.NamePattern,
+							).withSeverity(rule.Severity))
+						}
+					}
+				}
+			}
+		}
+
+		// Order the violations of each rule by package and type name, like
+		// InterfacesMustNotBePrefixedWithI does
+		ruleViolations := violations[start:]
+		sort.SliceStable(ruleViolations, func(x, y int) bool {
+			if ruleViolations[x].SourcePackage != ruleViolations[y].SourcePackage {
+				return ruleViolations[x].SourcePackage < ruleViolations[y].SourcePackage
+			}
+			return ruleViolations[x].Message < ruleViolations[y].Message
+		})
+	}
+
+	return violations
+}
+
+// ValidateNamingRules validates that type names match the naming conventions of their layer
+// Rules with SeverityWarning are reported but don't make the validation fail.
+func (a *Architecture) ValidateNamingRules(rules []*NamingRule) (bool, []string) {
+	valid, violations := a.ValidateNamingRulesDetailed(rules)
+	return valid, violationStrings(violations)
+}
+
+// ValidateNamingRulesDetailed validates that type names match the naming conventions of
+// their layer and returns structured violations
+func (a *Architecture) ValidateNamingRulesDetailed(rules []*NamingRule) (bool, []Violation) {
+	violations := a.checkNamingRules(rules)
+	return !HasErrors(violations), violations
+}
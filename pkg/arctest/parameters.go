@@ -2,16 +2,21 @@ package arctest
 
 import (
 	"fmt"
+	"go/token"
 	"regexp"
 	"strings"
 )
 
 // ParameterRule represents a rule for checking method parameters
 type ParameterRule struct {
-	StructPattern             string // regex pattern for struct names
-	MethodPattern             string // regex pattern for method names
-	ParameterTypePattern      string // regex pattern for parameter types to check
-	ShouldUseInterface        bool   // if true, parameters should be interfaces, if false, they should be structs
+	StructPattern             string   // regex pattern for struct names
+	MethodPattern             string   // regex pattern for method names
+	ParameterTypePattern      string   // regex pattern for parameter types to check
+	ShouldUseInterface        bool     // if true, parameters should be interfaces, if false, they should be structs
+	Layer                     *Layer   // optional layer whose structs are checked
+	IncludeFreeFunctions      bool     // if true, package-level functions matching the method pattern are checked too
+	ExportedOnly              bool     // if true, unexported structs and functions are skipped
+	Severity                  Severity // severity of violations, SeverityError if empty
 	structPatternRegex        *regexp.Regexp
 	methodPatternRegex        *regexp.Regexp
 	parameterTypePatternRegex *regexp.Regexp
@@ -47,32 +52,29 @@ func NewParameterRule(structPattern, methodPattern, parameterTypePattern string,
 
 // CheckMethodParameters checks if method parameters match the required type (interface or struct)
 func (a *Architecture) CheckMethodParameters(rules []*ParameterRule) ([]string, error) {
-	violations := []string{}
-
-	// Build a quick lookup of which types are interfaces and which are structs
-	interfaces := make(map[string]bool)
-	structs := make(map[string]bool)
+	return violationStrings(a.checkMethodParameters(rules)), nil
+}
 
-	for _, pkg := range a.Packages {
-		pkgPrefix := pkg.Name + "."
-		for name := range pkg.Interfaces {
-			interfaces[name] = true
-			interfaces[pkgPrefix+name] = true
-		}
-		for name := range pkg.Structs {
-			structs[name] = true
-			structs[pkgPrefix+name] = true
-		}
-	}
+// checkMethodParameters checks if method parameters match the required type (interface or struct)
+func (a *Architecture) checkMethodParameters(rules []*ParameterRule) []Violation {
+	violations := []Violation{}
+	interfaces, structs := a.typeKinds()
 
 	// For each rule
 	for _, rule := range rules {
 		// For each package
 		for _, pkg := range a.Packages {
+			if rule.Layer != nil && !rule.Layer.Contains(pkg.Path) {
+				continue
+			}
+
 			// For each struct
 			for _, s := range pkg.Structs {
 				// Check if the struct matches the pattern
-				if !rule.structPatternRegex.MatchString(s.Name) {
+				if !rule.structPatternRegex.MatchString(a.ruleTypeName(pkg, s.Name)) {
+					continue
+				}
+				if rule.ExportedOnly && !token.IsExported(s.Name) {
 					continue
 				}
 
@@ -83,51 +85,265 @@ func (a *Architecture) CheckMethodParameters(rules []*ParameterRule) ([]string,
 						continue
 					}
 
-					// For each parameter
-					for _, p := range m.Params {
-						// Skip empty or primitive types
-						if p.Type == "" || isPrimitiveType(p.Type) {
-							continue
+					for _, paramType := range rule.mismatchedParameters(a, pkg, m.Params, interfaces, structs) {
+						if rule.ShouldUseInterface {
+							violations = append(violations, newViolation(RuleTypeParameter, m.Position, s.Pkg.Path, "",
+								"Method %q of struct %q in package %q uses struct type %q as parameter, but should use an interface",
+								m.Name, s.Name, s.Pkg.Path, paramType,
+							).withSeverity(rule.Severity))
+						} else {
+							violations = append(violations, newViolation(RuleTypeParameter, m.Position, s.Pkg.Path, "",
+								"Method %q of struct %q in package %q uses interface type %q as parameter, but should use a struct",
+								m.Name, s.Name, s.Pkg.Path, paramType,
+							).withSeverity(rule.Severity))
 						}
+					}
+				}
+			}
 
-						// Remove pointer prefix if exists
-						paramType := p.Type
-						if strings.HasPrefix(paramType, "*") {
-							paramType = paramType[1:]
-						}
+			if !rule.IncludeFreeFunctions {
+				continue
+			}
 
-						// Check if the parameter type matches the pattern
-						if !rule.parameterTypePatternRegex.MatchString(paramType) {
-							continue
-						}
+			// Package-level functions such as constructors are matched by the method pattern
+			for _, f := range pkg.Functions {
+				if !rule.methodPatternRegex.MatchString(f.Name) {
+					continue
+				}
+				if rule.ExportedOnly && !token.IsExported(f.Name) {
+					continue
+				}
 
-						isInterface := interfaces[paramType]
-						isStruct := structs[paramType]
+				for _, paramType := range rule.mismatchedParameters(a, pkg, f.Params, interfaces, structs) {
+					if rule.ShouldUseInterface {
+						violations = append(violations, newViolation(RuleTypeParameter, f.Position, pkg.Path, "",
+							"Function %q in package %q uses struct type %q as parameter, but should use an interface",
+							f.Name, pkg.Path, paramType,
+						).withSeverity(rule.Severity))
+					} else {
+						violations = append(violations, newViolation(RuleTypeParameter, f.Position, pkg.Path, "",
+							"Function %q in package %q uses interface type %q as parameter, but should use a struct",
+							f.Name, pkg.Path, paramType,
+						).withSeverity(rule.Severity))
+					}
+				}
+			}
+		}
+	}
 
-						// If we can't determine the type, skip it
-						if !isInterface && !isStruct {
-							continue
-						}
+	return violations
+}
 
-						// Check if the parameter type matches the rule
-						if rule.ShouldUseInterface && !isInterface {
-							violations = append(violations, fmt.Sprintf(
-								"Method %q of struct %q in package %q uses struct type %q as parameter, but should use an interface",
-								m.Name, s.Name, s.Pkg.Path, paramType,
-							))
-						} else if !rule.ShouldUseInterface && !isStruct {
-							violations = append(violations, fmt.Sprintf(
-								"Method %q of struct %q in package %q uses interface type %q as parameter, but should use a struct",
-								m.Name, s.Name, s.Pkg.Path, paramType,
-							))
-						}
+// parameterRuleMatches counts the structs, and with IncludeFreeFunctions the functions,
+// a parameter rule applies to
+func (a *Architecture) parameterRuleMatches(rule *ParameterRule) int {
+	count := 0
+	for _, pkg := range a.Packages {
+		if rule.Layer != nil && !rule.Layer.Contains(pkg.Path) {
+			continue
+		}
+
+		for _, s := range pkg.Structs {
+			if rule.structPatternRegex.MatchString(a.ruleTypeName(pkg, s.Name)) && (!rule.ExportedOnly || token.IsExported(s.Name)) {
+				count++
+			}
+		}
+
+		if !rule.IncludeFreeFunctions {
+			continue
+		}
+		for _, f := range pkg.Functions {
+			if rule.methodPatternRegex.MatchString(f.Name) && (!rule.ExportedOnly || token.IsExported(f.Name)) {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// mismatchedParameters returns the types of the parameters that match the rule's parameter
+// type pattern but are a struct where an interface is required or vice versa. Parameter
+// types are resolved through the imports of the declaring package where possible.
+func (r *ParameterRule) mismatchedParameters(a *Architecture, pkg *Package, params []*Parameter, interfaces, structs map[string]bool) []string {
+	mismatched := []string{}
+
+	// For each parameter
+	for _, p := range params {
+		// Skip empty or primitive types
+		if p.Type == "" || isPrimitiveType(p.Type) {
+			continue
+		}
+
+		// Look at the named type behind pointers, slices, maps and channels
+		paramType := elementTypeName(p.Type)
+
+		// Check if the parameter type matches the pattern
+		if !r.parameterTypePatternRegex.MatchString(paramType) {
+			continue
+		}
+
+		isInterface, isStruct := a.typeKind(pkg, paramType, interfaces, structs)
+
+		// If we can't determine the type, skip it
+		if !isInterface && !isStruct {
+			continue
+		}
+
+		// Check if the parameter type matches the rule
+		if (r.ShouldUseInterface && !isInterface) || (!r.ShouldUseInterface && !isStruct) {
+			mismatched = append(mismatched, paramType)
+		}
+	}
+
+	return mismatched
+}
+
+// typeKind classifies a named type referenced in the package as an interface or a struct,
+// preferring the declaring package's type and falling back to a lookup by name. Both
+// results are false if the type is unknown.
+func (a *Architecture) typeKind(pkg *Package, typeName string, interfaces, structs map[string]bool) (bool, bool) {
+	if isAnonymousInterface(typeName) {
+		return true, false
+	}
+	if key, ok := a.typeKey(pkg, typeName); ok && (interfaces[key] || structs[key]) {
+		return interfaces[key], structs[key]
+	}
+	return interfaces[typeName], structs[typeName]
+}
+
+// typeKinds builds a quick lookup of which type names are interfaces and which are
+// structs, keyed by their plain and package-qualified names as well as by their import
+// path qualified names, see typeKey. Named types and aliases take the kind of the type
+// they are declared with.
+func (a *Architecture) typeKinds() (map[string]bool, map[string]bool) {
+	interfaces := make(map[string]bool)
+	structs := make(map[string]bool)
+
+	for _, pkg := range a.Packages {
+		pkgPrefix := pkg.Name + "."
+		pathPrefix := a.ImportPath(pkg.Path) + "."
+		for name := range pkg.Interfaces {
+			interfaces[name] = true
+			interfaces[pkgPrefix+name] = true
+			interfaces[pathPrefix+name] = true
+		}
+		for name := range pkg.Structs {
+			structs[name] = true
+			structs[pkgPrefix+name] = true
+			structs[pathPrefix+name] = true
+		}
+	}
+
+	// Repeat until nothing changes to follow chains of declarations, e.g. an alias of
+	// a named type declared with an interface
+	for changed := true; changed; {
+		changed = false
+		for _, pkg := range a.Packages {
+			pkgPrefix := pkg.Name + "."
+			pathPrefix := a.ImportPath(pkg.Path) + "."
+			for _, types := range []map[string]*NamedType{pkg.NamedTypes, pkg.TypeAliases} {
+				for name, t := range types {
+					underlying := qualifyType(t.Underlying, pkg)
+					if key, ok := a.typeKey(pkg, t.Underlying); ok && (interfaces[key] || structs[key]) {
+						underlying = key
+					}
+					if (interfaces[underlying] || isAnonymousInterface(underlying)) && !interfaces[pkgPrefix+name] {
+						interfaces[name] = true
+						interfaces[pkgPrefix+name] = true
+						interfaces[pathPrefix+name] = true
+						changed = true
+					}
+					if structs[underlying] && !structs[pkgPrefix+name] {
+						structs[name] = true
+						structs[pkgPrefix+name] = true
+						structs[pathPrefix+name] = true
+						changed = true
 					}
 				}
 			}
 		}
 	}
 
-	return violations, nil
+	return interfaces, structs
+}
+
+// typeKey qualifies a named type referenced in the package by the import path of the
+// package declaring it, resolving import aliases, e.g. d.User with d "mymod/domain" becomes
+// mymod/domain.User. The second result is false if the type cannot be resolved.
+func (a *Architecture) typeKey(pkg *Package, typeName string) (string, bool) {
+	dot := strings.Index(typeName, ".")
+	if dot < 0 {
+		_, isInterface := pkg.Interfaces[typeName]
+		_, isStruct := pkg.Structs[typeName]
+		_, isNamed := pkg.NamedTypes[typeName]
+		_, isAlias := pkg.TypeAliases[typeName]
+		if !isInterface && !isStruct && !isNamed && !isAlias {
+			return "", false
+		}
+		return a.ImportPath(pkg.Path) + "." + typeName, true
+	}
+
+	importPath, ok := pkg.ImportedPkgs[typeName[:dot]]
+	if !ok {
+		return "", false
+	}
+	pkgPath, ok := a.ResolveImport(importPath)
+	if !ok {
+		return "", false
+	}
+	return a.ImportPath(pkgPath) + typeName[dot:], true
+}
+
+// isAnonymousInterface checks if a rendered type is any or an interface type literal,
+// e.g. interface{} or interface{ Log(string) }
+func isAnonymousInterface(typeName string) bool {
+	return typeName == "any" || strings.HasPrefix(typeName, "interface{")
+}
+
+// elementTypeName strips pointer, slice, array, variadic, map and channel wrappers from
+// a rendered type, so that []*domain.User yields domain.User and map[string]Event yields Event
+func elementTypeName(typeName string) string {
+	for {
+		switch {
+		case strings.HasPrefix(typeName, "*"):
+			typeName = typeName[1:]
+		case strings.HasPrefix(typeName, "..."):
+			typeName = typeName[3:]
+		case strings.HasPrefix(typeName, "["):
+			end := strings.Index(typeName, "]")
+			if end < 0 {
+				return typeName
+			}
+			typeName = typeName[end+1:]
+		case strings.HasPrefix(typeName, "map["):
+			typeName = typeName[mapValueOffset(typeName):]
+		case strings.HasPrefix(typeName, "chan<- "):
+			typeName = typeName[len("chan<- "):]
+		case strings.HasPrefix(typeName, "<-chan "):
+			typeName = typeName[len("<-chan "):]
+		case strings.HasPrefix(typeName, "chan "):
+			typeName = typeName[len("chan "):]
+		default:
+			return typeName
+		}
+	}
+}
+
+// mapValueOffset returns the index at which the value type of a rendered map type starts
+func mapValueOffset(typeName string) int {
+	depth := 0
+	for idx := len("map"); idx < len(typeName); idx++ {
+		switch typeName[idx] {
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				return idx + 1
+			}
+		}
+	}
+	return len(typeName)
 }
 
 // isPrimitiveType checks if a type is a primitive Go type
@@ -169,7 +385,15 @@ func (a *Architecture) MethodsShouldUseStructParameters(structPattern, methodPat
 }
 
 // ValidateMethodParameters validates that method parameters match the required type
+// Rules with SeverityWarning are reported but don't make the validation fail.
 func (a *Architecture) ValidateMethodParameters(rules []*ParameterRule) (bool, []string) {
-	violations, _ := a.CheckMethodParameters(rules)
-	return len(violations) == 0, violations
+	valid, violations := a.ValidateMethodParametersDetailed(rules)
+	return valid, violationStrings(violations)
+}
+
+// ValidateMethodParametersDetailed validates that method parameters match the required type
+// and returns structured violations
+func (a *Architecture) ValidateMethodParametersDetailed(rules []*ParameterRule) (bool, []Violation) {
+	violations := a.checkMethodParameters(rules)
+	return !HasErrors(violations), violations
 }
@@ -0,0 +1,71 @@
+package arctest
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// PatternType selects how package patterns of a layer are interpreted
+type PatternType string
+
+const (
+	// PatternRegex interprets patterns as regular expressions (the default)
+	PatternRegex PatternType = "regex"
+	// PatternGlob interprets patterns as path globs, where "*" matches within a single
+	// path segment and "**" matches any number of segments
+	PatternGlob PatternType = "glob"
+)
+
+// GlobToRegex translates a path glob into an anchored regular expression.
+// "**/" matches zero or more leading segments, a trailing "/**" matches the
+// package itself and everything below it, "*" matches within one segment and
+// "?" matches a single character other than "/".
+func GlobToRegex(glob string) string {
+	var sb strings.Builder
+	sb.WriteString("^")
+
+	for i := 0; i < len(glob); {
+		switch {
+		case strings.HasPrefix(glob[i:], "/**") && i+3 == len(glob):
+			sb.WriteString("(/.*)?")
+			i += 3
+		case strings.HasPrefix(glob[i:], "**/"):
+			sb.WriteString("(.*/)?")
+			i += 3
+		case strings.HasPrefix(glob[i:], "**"):
+			sb.WriteString(".*")
+			i += 2
+		case glob[i] == '*':
+			sb.WriteString("[^/]*")
+			i++
+		case glob[i] == '?':
+			sb.WriteString("[^/]")
+			i++
+		default:
+			sb.WriteString(regexp.QuoteMeta(glob[i : i+1]))
+			i++
+		}
+	}
+
+	sb.WriteString("$")
+	return sb.String()
+}
+
+// NewLayerWithPatternType creates a new layer whose package patterns are interpreted
+// according to the given pattern type. Glob patterns are translated to regular
+// expressions, which are what the layer's Packages field then holds.
+func NewLayerWithPatternType(name string, patternType PatternType, packages ...string) (*Layer, error) {
+	switch patternType {
+	case PatternRegex, "":
+		return NewLayer(name, packages...)
+	case PatternGlob:
+		regexes := make([]string, 0, len(packages))
+		for _, glob := range packages {
+			regexes = append(regexes, GlobToRegex(glob))
+		}
+		return NewLayer(name, regexes...)
+	default:
+		return nil, fmt.Errorf("unknown pattern type %q for layer %q", patternType, name)
+	}
+}
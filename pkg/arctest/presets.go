@@ -0,0 +1,78 @@
+package arctest
+
+// NewHexagonalArchitecture creates a layered architecture following the ports and adapters
+// pattern, with layers named "Domain", "Ports" and "Adapters". Adapters may depend on ports
+// and the domain, ports only on the domain, and the domain on no other layer. The reverse
+// dependencies are forbidden explicitly, so they are reported in the AllowByDefault policy
+// mode as well.
+func NewHexagonalArchitecture(arch *Architecture, domainPattern, portsPattern, adaptersPattern string) (*LayeredArchitecture, error) {
+	domain, err := NewLayer("Domain", domainPattern)
+	if err != nil {
+		return nil, err
+	}
+
+	ports, err := NewLayer("Ports", portsPattern)
+	if err != nil {
+		return nil, err
+	}
+
+	adapters, err := NewLayer("Adapters", adaptersPattern)
+	if err != nil {
+		return nil, err
+	}
+
+	layeredArch := arch.NewLayeredArchitecture(domain, ports, adapters)
+
+	if err := adapters.DependsOnLayer(ports); err != nil {
+		return nil, err
+	}
+	if err := adapters.DependsOnLayer(domain); err != nil {
+		return nil, err
+	}
+	if err := ports.DependsOnLayer(domain); err != nil {
+		return nil, err
+	}
+
+	for _, forbidden := range [][2]*Layer{{domain, ports}, {domain, adapters}, {ports, adapters}} {
+		if err := forbidden[0].MustNotDependOnLayer(forbidden[1]); err != nil {
+			return nil, err
+		}
+	}
+
+	return layeredArch, nil
+}
+
+// NewCleanArchitecture creates a layered architecture following Clean Architecture, with
+// concentric layers named "Entities", "UseCases", "InterfaceAdapters" and "Frameworks" from
+// the inside out. Dependencies may only point inward: every layer may depend on the layers
+// inside it, while the reverse dependencies are forbidden explicitly, so they are reported
+// in the AllowByDefault policy mode as well.
+func NewCleanArchitecture(arch *Architecture, entitiesPattern, useCasesPattern, interfaceAdaptersPattern, frameworksPattern string) (*LayeredArchitecture, error) {
+	names := []string{"Entities", "UseCases", "InterfaceAdapters", "Frameworks"}
+	patterns := []string{entitiesPattern, useCasesPattern, interfaceAdaptersPattern, frameworksPattern}
+
+	// Layers are ordered from the innermost to the outermost one
+	layers := make([]*Layer, 0, len(names))
+	for idx, name := range names {
+		layer, err := NewLayer(name, patterns[idx])
+		if err != nil {
+			return nil, err
+		}
+		layers = append(layers, layer)
+	}
+
+	layeredArch := arch.NewLayeredArchitecture(layers...)
+
+	for outer := range layers {
+		for inner := 0; inner < outer; inner++ {
+			if err := layers[outer].DependsOnLayer(layers[inner]); err != nil {
+				return nil, err
+			}
+			if err := layers[inner].MustNotDependOnLayer(layers[outer]); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return layeredArch, nil
+}
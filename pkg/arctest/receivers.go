@@ -0,0 +1,66 @@
+package arctest
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+)
+
+// CheckReceiverStyle checks that all methods of structs matching the pattern are declared
+// with a pointer receiver if wantPointer is true, or with a value receiver otherwise
+func (a *Architecture) CheckReceiverStyle(structPattern string, wantPointer bool) ([]string, error) {
+	violations, err := a.CheckReceiverStyleDetailed(structPattern, wantPointer)
+	if err != nil {
+		return nil, err
+	}
+	return violationStrings(violations), nil
+}
+
+// CheckReceiverStyleDetailed checks the receivers of the methods of structs matching the
+// pattern and returns structured violations
+func (a *Architecture) CheckReceiverStyleDetailed(structPattern string, wantPointer bool) ([]Violation, error) {
+	structRegex, err := regexp.Compile(structPattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid struct pattern: %w", err)
+	}
+
+	want, got := "pointer", "value"
+	if !wantPointer {
+		want, got = got, want
+	}
+
+	pkgPaths := make([]string, 0, len(a.Packages))
+	for pkgPath := range a.Packages {
+		pkgPaths = append(pkgPaths, pkgPath)
+	}
+	sort.Strings(pkgPaths)
+
+	violations := []Violation{}
+	for _, pkgPath := range pkgPaths {
+		pkg := a.Packages[pkgPath]
+
+		names := make([]string, 0, len(pkg.Structs))
+		for name := range pkg.Structs {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			if !structRegex.MatchString(name) {
+				continue
+			}
+
+			for _, m := range pkg.Structs[name].Methods {
+				if m.PointerReceiver == wantPointer {
+					continue
+				}
+				violations = append(violations, newViolation(RuleTypeReceiver, m.Position, pkgPath, "",
+					"Method %q of struct %q in package %q has a %s receiver, but should have a %s receiver",
+					m.Name, name, pkgPath, got, want,
+				))
+			}
+		}
+	}
+
+	return violations, nil
+}
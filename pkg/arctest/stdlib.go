@@ -0,0 +1,51 @@
+package arctest
+
+import (
+	"go/build"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+//go:generate go run gen_stdlib.go
+
+// stdlibCache remembers which import paths were found in GOROOT
+var stdlibCache sync.Map
+
+// IsStandardLibrary checks if an import path refers to a standard library package such
+// as "fmt" or "net/http". Standard library paths have no dot in their first element and
+// exist below GOROOT/src; if GOROOT isn't available, they are looked up in a list generated
+// from the Go release the library was built with, so that dotless module paths such as
+// "domain" aren't mistaken for the standard library.
+func IsStandardLibrary(importPath string) bool {
+	if importPath == "" {
+		return false
+	}
+	if importPath == "C" {
+		// cgo pseudo-package
+		return true
+	}
+
+	first := importPath
+	if idx := strings.Index(importPath, "/"); idx >= 0 {
+		first = importPath[:idx]
+	}
+	if strings.Contains(first, ".") {
+		return false
+	}
+
+	if cached, ok := stdlibCache.Load(importPath); ok {
+		return cached.(bool)
+	}
+
+	goroot := build.Default.GOROOT
+	std := stdlibPackages[importPath]
+	if info, err := os.Stat(filepath.Join(goroot, "src")); goroot != "" && err == nil && info.IsDir() {
+		info, err := os.Stat(filepath.Join(goroot, "src", filepath.FromSlash(importPath)))
+		std = err == nil && info.IsDir()
+	}
+
+	stdlibCache.Store(importPath, std)
+	return std
+}
@@ -0,0 +1,209 @@
+package arctest
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Suite parses a project once and collects rules, so that a single test can check all of
+// them and report every violation in one combined block
+type Suite struct {
+	Arch   *Architecture
+	checks []suiteCheck
+	stats  []RuleStats // statistics of the last Run
+}
+
+// suiteCheck is a named group of rules run by a suite
+type suiteCheck struct {
+	name    string
+	check   func() ([]Violation, error)
+	matches func() int // counts what the rules apply to, nil if unknown
+}
+
+// RuleStats reports how many packages or types a rule of a suite applied to and how
+// many violations it produced. A rule matching nothing usually has a wrong pattern.
+type RuleStats struct {
+	Name       string
+	Matches    int // packages for dependency and layer rules, structs and functions for others; negative if unknown
+	Violations int // errors and warnings
+	Suppressed int // violations suppressed by //arctest:ignore comments
+}
+
+// NewSuite creates a suite for the project at basePath and parses all of its packages
+func NewSuite(basePath string, opts ...Option) (*Suite, error) {
+	arch, err := New(basePath, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err := arch.ParsePackages(); err != nil {
+		return nil, err
+	}
+	return &Suite{Arch: arch}, nil
+}
+
+// AddDependencyRules adds dependency rules to the suite, each reported as its own group
+func (s *Suite) AddDependencyRules(rules ...*DependencyRule) *Suite {
+	for _, rule := range rules {
+		rule := rule
+		verb := "cannot import"
+		switch {
+		case rule.Exclusive:
+			verb = "may only import"
+		case rule.AllowedImports:
+			verb = "may import"
+		}
+		s.addCheck(fmt.Sprintf("Dependency rule: %s %s %s", rule.SourcePattern, verb, rule.TargetPattern), func() ([]Violation, error) {
+			return s.Arch.checkDependencies([]*DependencyRule{rule}, nil), nil
+		}, func() int {
+			return s.Arch.dependencyRuleMatches(rule)
+		})
+	}
+	return s
+}
+
+// AddInterfaceRules adds interface implementation rules to the suite. Interface names the
+// rules match in several packages are reported as warnings.
+func (s *Suite) AddInterfaceRules(rules ...*InterfaceImplementationRule) *Suite {
+	for _, rule := range rules {
+		rule := rule
+		s.addCheck(fmt.Sprintf("Interface rule: structs matching %s implement %s", rule.StructPattern, rule.InterfacePattern), func() ([]Violation, error) {
+			rules := []*InterfaceImplementationRule{rule}
+			return append(s.Arch.checkStructImplementsInterfaces(rules), s.Arch.InterfaceRuleWarnings(rules)...), nil
+		}, func() int {
+			return s.Arch.interfaceRuleMatches(rule)
+		})
+	}
+	return s
+}
+
+// AddParameterRules adds method parameter rules to the suite. Struct names the rules match
+// in several packages are reported as warnings.
+func (s *Suite) AddParameterRules(rules ...*ParameterRule) *Suite {
+	for _, rule := range rules {
+		rule := rule
+		s.addCheck(fmt.Sprintf("Parameter rule: methods matching %s of structs matching %s", rule.MethodPattern, rule.StructPattern), func() ([]Violation, error) {
+			rules := []*ParameterRule{rule}
+			return append(s.Arch.checkMethodParameters(rules), s.Arch.ParameterRuleWarnings(rules)...), nil
+		}, func() int {
+			return s.Arch.parameterRuleMatches(rule)
+		})
+	}
+	return s
+}
+
+// AddLayeredArchitecture adds the layer rules of a layered architecture to the suite
+func (s *Suite) AddLayeredArchitecture(la *LayeredArchitecture) *Suite {
+	names := make([]string, 0, len(la.Layers))
+	for _, layer := range la.Layers {
+		names = append(names, layer.Name)
+	}
+	s.addCheck("Layered architecture: "+strings.Join(names, ", "), la.CheckDetailed, la.layeredPackageCount)
+	return s
+}
+
+// AddCheck adds a custom check to the suite, e.g. func() []string { _, v := arch.HasNoCycles(); return v }.
+// Every string it returns is reported as an error.
+func (s *Suite) AddCheck(name string, check func() []string) *Suite {
+	s.addCheck(name, func() ([]Violation, error) {
+		violations := []Violation{}
+		for _, message := range check() {
+			violations = append(violations, Violation{Severity: SeverityError, Message: message})
+		}
+		return violations, nil
+	}, nil)
+	return s
+}
+
+// AddViolationCheck adds a custom check returning structured violations to the suite, e.g.
+// func() []arctest.Violation { return arch.CheckCouplingDetailed(10, 8) }. Unlike AddCheck,
+// severities are kept and ignore comments apply.
+func (s *Suite) AddViolationCheck(name string, check func() []Violation) *Suite {
+	s.addCheck(name, func() ([]Violation, error) {
+		return check(), nil
+	}, nil)
+	return s
+}
+
+// addCheck registers a named group of rules, with an optional function counting its matches
+func (s *Suite) addCheck(name string, check func() ([]Violation, error), matches func() int) {
+	s.checks = append(s.checks, suiteCheck{name: name, check: check, matches: matches})
+}
+
+// Stats returns the statistics of every rule from the last Run, in the order the rules were added
+func (s *Suite) Stats() []RuleStats {
+	return s.stats
+}
+
+// Run checks all rules of the suite and fails the test once with a report of every
+// error-level violation, grouped by rule. Warnings, including rules that match nothing,
+// are logged in the same format. Violations suppressed by //arctest:ignore comments are
+// only counted.
+func (s *Suite) Run(t TestingT) {
+	t.Helper()
+
+	var errs, warnings strings.Builder
+	errCount, warningCount, suppressedCount := 0, 0, 0
+	s.stats = make([]RuleStats, 0, len(s.checks))
+	for _, c := range s.checks {
+		violations, err := c.check()
+		if err != nil {
+			fmt.Fprintf(&errs, "%s:\n  - failed to run: %v\n", c.name, err)
+			errCount++
+			s.stats = append(s.stats, RuleStats{Name: c.name, Matches: -1})
+			continue
+		}
+
+		violations, suppressed := s.Arch.FilterIgnored(violations)
+		suppressedCount += suppressed
+
+		stats := RuleStats{Name: c.name, Matches: -1, Violations: len(violations), Suppressed: suppressed}
+		if c.matches != nil {
+			stats.Matches = c.matches()
+		}
+		s.stats = append(s.stats, stats)
+
+		var groupErrs, groupWarnings []string
+		for _, v := range violations {
+			if v.IsWarning() {
+				groupWarnings = append(groupWarnings, v.String())
+			} else {
+				groupErrs = append(groupErrs, v.String())
+			}
+		}
+		if stats.Matches == 0 {
+			groupWarnings = append(groupWarnings, "warning: rule matches nothing, check its patterns")
+		}
+		writeGroup(&errs, c.name, groupErrs)
+		writeGroup(&warnings, c.name, groupWarnings)
+		errCount += len(groupErrs)
+		warningCount += len(groupWarnings)
+	}
+
+	ignoreWarnings := []string{}
+	for _, v := range s.Arch.IgnoreWarnings() {
+		ignoreWarnings = append(ignoreWarnings, v.String())
+	}
+	writeGroup(&warnings, "Ignore comments", ignoreWarnings)
+	warningCount += len(ignoreWarnings)
+
+	if suppressedCount > 0 {
+		t.Logf("%d architecture violation(s) suppressed by arctest:ignore comments", suppressedCount)
+	}
+	if warningCount > 0 {
+		t.Logf("%d architecture warning(s):\n%s", warningCount, warnings.String())
+	}
+	if errCount > 0 {
+		t.Errorf("%d architecture violation(s):\n%s", errCount, errs.String())
+	}
+}
+
+// writeGroup writes the violations of a rule as an indented list below its name
+func writeGroup(b *strings.Builder, name string, violations []string) {
+	if len(violations) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "%s:\n", name)
+	for _, v := range violations {
+		fmt.Fprintf(b, "  - %s\n", v)
+	}
+}
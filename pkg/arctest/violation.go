@@ -0,0 +1,151 @@
+package arctest
+
+import (
+	"fmt"
+	"go/token"
+)
+
+// RuleType identifies the kind of rule that produced a violation
+type RuleType string
+
+const (
+	// RuleTypeDependency is used for violations of package dependency rules
+	RuleTypeDependency RuleType = "dependency"
+	// RuleTypeLayer is used for violations of layered architecture rules
+	RuleTypeLayer RuleType = "layer"
+	// RuleTypeInterfaceImplementation is used for violations of interface implementation rules
+	RuleTypeInterfaceImplementation RuleType = "interface_implementation"
+	// RuleTypeParameter is used for violations of method parameter rules
+	RuleTypeParameter RuleType = "parameter"
+	// RuleTypeField is used for violations of struct field rules
+	RuleTypeField RuleType = "field"
+	// RuleTypeConstructor is used for violations of constructor rules
+	RuleTypeConstructor RuleType = "constructor"
+	// RuleTypeNaming is used for violations of naming convention rules
+	RuleTypeNaming RuleType = "naming"
+	// RuleTypeImportStyle is used for violations of import style rules
+	RuleTypeImportStyle RuleType = "import_style"
+	// RuleTypeReceiver is used for violations of method receiver rules
+	RuleTypeReceiver RuleType = "receiver"
+	// RuleTypeCall is used for violations of forbidden call rules
+	RuleTypeCall RuleType = "call"
+	// RuleTypePackageDepth is used for violations of package nesting limits
+	RuleTypePackageDepth RuleType = "package_depth"
+	// RuleTypeGlobal is used for violations of package-level variable rules
+	RuleTypeGlobal RuleType = "global"
+	// RuleTypeCoupling is used for violations of coupling thresholds and stability rules
+	RuleTypeCoupling RuleType = "coupling"
+	// RuleTypeIgnore is used for warnings about malformed //arctest:ignore comments. It can't
+	// be named in these comments itself.
+	RuleTypeIgnore RuleType = "ignore"
+)
+
+// ruleTypes lists every rule type, so that names given by users can be validated
+var ruleTypes = []RuleType{
+	RuleTypeDependency,
+	RuleTypeLayer,
+	RuleTypeInterfaceImplementation,
+	RuleTypeParameter,
+	RuleTypeField,
+	RuleTypeConstructor,
+	RuleTypeNaming,
+	RuleTypeImportStyle,
+	RuleTypeReceiver,
+	RuleTypeCall,
+	RuleTypePackageDepth,
+	RuleTypeGlobal,
+	RuleTypeCoupling,
+}
+
+// Severity determines whether a violation fails validation
+type Severity string
+
+const (
+	// SeverityError marks violations that fail validation. Rules without an explicit
+	// severity use this level.
+	SeverityError Severity = "error"
+	// SeverityWarning marks violations that are reported but don't fail validation,
+	// which allows staging in new rules
+	SeverityWarning Severity = "warning"
+)
+
+// Violation represents a single violation of an architecture rule
+type Violation struct {
+	RuleType      RuleType
+	Severity      Severity
+	SourcePackage string // package containing the offending code
+	TargetPackage string // package the offending code depends on, empty if not applicable
+	File          string // file containing the offending code, empty if unknown
+	Line          int
+	Column        int
+	Message       string
+}
+
+// newViolation creates a violation of the given rule type located at the given position
+func newViolation(ruleType RuleType, pos token.Position, sourcePkg, targetPkg string, format string, args ...interface{}) Violation {
+	return Violation{
+		RuleType:      ruleType,
+		Severity:      SeverityError,
+		SourcePackage: sourcePkg,
+		TargetPackage: targetPkg,
+		File:          pos.Filename,
+		Line:          pos.Line,
+		Column:        pos.Column,
+		Message:       fmt.Sprintf(format, args...),
+	}
+}
+
+// Position returns the location of the violation in the source code
+func (v Violation) Position() token.Position {
+	return token.Position{
+		Filename: v.File,
+		Line:     v.Line,
+		Column:   v.Column,
+	}
+}
+
+// IsWarning checks if the violation is only a warning
+func (v Violation) IsWarning() bool {
+	return v.Severity == SeverityWarning
+}
+
+// String formats the violation as "file:line:col: message", leaving out the location if unknown.
+// Warnings are prefixed with "warning: ".
+func (v Violation) String() string {
+	message := v.Message
+	if v.IsWarning() {
+		message = "warning: " + message
+	}
+	if v.File == "" {
+		return message
+	}
+	return fmt.Sprintf("%s:%d:%d: %s", v.File, v.Line, v.Column, message)
+}
+
+// withSeverity returns the violation with the given severity, keeping the default
+// error level if the severity is empty
+func (v Violation) withSeverity(severity Severity) Violation {
+	if severity != "" {
+		v.Severity = severity
+	}
+	return v
+}
+
+// HasErrors checks if any of the violations is an error rather than a warning
+func HasErrors(violations []Violation) bool {
+	for _, v := range violations {
+		if !v.IsWarning() {
+			return true
+		}
+	}
+	return false
+}
+
+// violationStrings formats each violation using Violation.String
+func violationStrings(violations []Violation) []string {
+	result := make([]string, 0, len(violations))
+	for _, v := range violations {
+		result = append(result, v.String())
+	}
+	return result
+}
@@ -0,0 +1,81 @@
+package arctest
+
+import (
+	"sort"
+	"strings"
+)
+
+// CheckInternalVisibility reports imports of internal packages from outside the tree they
+// are visible in. As enforced by the go command, a package .../a/internal/b may only be
+// imported by packages in the tree rooted at .../a.
+func (a *Architecture) CheckInternalVisibility() []string {
+	return violationStrings(a.checkInternalVisibility())
+}
+
+// checkInternalVisibility reports imports of internal packages from outside their parent tree
+func (a *Architecture) checkInternalVisibility() []Violation {
+	violations := []Violation{}
+
+	pkgPaths := make([]string, 0, len(a.Packages))
+	for pkgPath := range a.Packages {
+		pkgPaths = append(pkgPaths, pkgPath)
+	}
+	sort.Strings(pkgPaths)
+
+	for _, pkgPath := range pkgPaths {
+		pkg := a.Packages[pkgPath]
+
+		// External test packages share the directory of the package they test
+		importer := pkgPath
+		if pkg.IsTest {
+			importer = strings.TrimSuffix(importer, "_test")
+		}
+		importer = a.ImportPath(importer)
+
+		for idx, importPath := range pkg.Imports {
+			target := importPath
+			if resolved, ok := a.ResolveImport(importPath); ok {
+				target = a.ImportPath(resolved)
+			}
+
+			parent, ok := internalParent(target)
+			if !ok {
+				continue
+			}
+
+			switch {
+			case parent == "":
+				// Top-level internal packages are visible to the whole tree, except for
+				// those of the standard library
+				if IsStandardLibrary(target) && !IsStandardLibrary(importer) {
+					violations = append(violations, newViolation(RuleTypeDependency, pkg.importPosition(idx), pkgPath, importPath,
+						"Package %q imports internal package %q, which is only visible within the standard library",
+						pkgPath, importPath,
+					))
+				}
+			case importer != parent && !strings.HasPrefix(importer, parent+"/"):
+				violations = append(violations, newViolation(RuleTypeDependency, pkg.importPosition(idx), pkgPath, importPath,
+					"Package %q imports internal package %q, which is only visible within %q",
+					pkgPath, importPath, parent,
+				))
+			}
+		}
+	}
+
+	return violations
+}
+
+// internalParent returns the path of the tree an internal package is visible in, that is the
+// path up to its last "internal" element. The second result is false for other packages.
+func internalParent(importPath string) (string, bool) {
+	if strings.HasSuffix(importPath, "/internal") {
+		return strings.TrimSuffix(importPath, "/internal"), true
+	}
+	if idx := strings.LastIndex(importPath, "/internal/"); idx >= 0 {
+		return importPath[:idx], true
+	}
+	if importPath == "internal" || strings.HasPrefix(importPath, "internal/") {
+		return "", true
+	}
+	return "", false
+}